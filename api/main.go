@@ -5,15 +5,23 @@ import (
 	"flag"
 	"log"
 	"net/http"
+	"time"
 
-	"git.neds.sh/matty/entain/api/proto/racing"
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/Kim-Hardie/entain-master/proto/sports"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+
+	// Register the error detail types the services attach to their errors,
+	// so the gateway can render them in error responses.
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
 var (
-	apiEndpoint  = flag.String("api-endpoint", "localhost:8000", "API endpoint")
-	grpcEndpoint = flag.String("grpc-endpoint", "localhost:9000", "gRPC server endpoint")
+	apiEndpoint        = flag.String("api-endpoint", "localhost:8000", "API endpoint")
+	grpcEndpoint       = flag.String("grpc-endpoint", "localhost:9000", "gRPC server endpoint")
+	sportsGrpcEndpoint = flag.String("sports-grpc-endpoint", "localhost:9001", "Sports gRPC server endpoint")
+	requestTimeout     = flag.Duration("request-timeout", 10*time.Second, "Deadline for calls to the gRPC servers, used when a request has no Grpc-Timeout header")
 )
 
 func main() {
@@ -29,6 +37,10 @@ func run() error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	// The deadline travels with each call, so the services stop work,
+	// including database queries, once it passes or the client goes away.
+	runtime.DefaultContextTimeout = *requestTimeout
+
 	mux := runtime.NewServeMux()
 	if err := racing.RegisterRacingHandlerFromEndpoint(
 		ctx,
@@ -38,6 +50,14 @@ func run() error {
 	); err != nil {
 		return err
 	}
+	if err := sports.RegisterSportsHandlerFromEndpoint(
+		ctx,
+		mux,
+		*sportsGrpcEndpoint,
+		[]grpc.DialOption{grpc.WithInsecure()},
+	); err != nil {
+		return err
+	}
 
 	log.Printf("API server listening on: %s\n", *apiEndpoint)
 
@@ -0,0 +1,10 @@
+// Package proto is the single source of truth for the racing and sports APIs.
+// The racing, sports and api modules all import the code generated here, so
+// the gRPC services and the REST gateway can't drift apart.
+package proto
+
+// Lint the protos, check for breaking changes against the last commit (or
+// BUF_BREAKING_AGAINST, e.g. in CI), then regenerate the Go code.
+//go:generate buf lint
+//go:generate sh -c "buf breaking --against \"$DOLLAR{BUF_BREAKING_AGAINST:-../.git#ref=HEAD,subdir=proto}\""
+//go:generate buf generate
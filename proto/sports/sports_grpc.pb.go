@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package sports
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SportsClient is the client API for Sports service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SportsClient interface {
+	// ListEvents returns a collection of sports events. Filters are passed as
+	// query parameters, e.g. ?filter.sport=AFL&filter.start_time_from=2021-03-02T00:00:00Z
+	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	// GetEvent returns a single event by its ID.
+	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error)
+	// Deprecated: Do not use.
+	// ListMatches returns a collection of all matches. Filters are passed as
+	// query parameters, e.g. ?filter.sport=AFL&filter.start_time_from=2021-03-02T00:00:00Z
+	// Deprecated: use ListEvents. Kept while clients migrate.
+	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error)
+	// Deprecated: Do not use.
+	// GetMatchByID returns a single match by its ID.
+	// Deprecated: use GetEvent. Kept while clients migrate.
+	GetMatchByID(ctx context.Context, in *GetMatchByIDRequest, opts ...grpc.CallOption) (*GetMatchByIDResponse, error)
+	// SearchMatches returns the matches whose name or teams contain every word
+	// of a query, best matches first, with the matching words highlighted. It
+	// needs the service built with SQLite FTS5 and otherwise fails with
+	// UNIMPLEMENTED. Like GetMatchByID it has no REST route.
+	SearchMatches(ctx context.Context, in *SearchMatchesRequest, opts ...grpc.CallOption) (*SearchMatchesResponse, error)
+}
+
+type sportsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSportsClient(cc grpc.ClientConnInterface) SportsClient {
+	return &sportsClient{cc}
+}
+
+func (c *sportsClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
+	out := new(ListEventsResponse)
+	err := c.cc.Invoke(ctx, "/sports.Sports/ListEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/sports.Sports/GetEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Deprecated: Do not use.
+func (c *sportsClient) ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error) {
+	out := new(ListMatchesResponse)
+	err := c.cc.Invoke(ctx, "/sports.Sports/ListMatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Deprecated: Do not use.
+func (c *sportsClient) GetMatchByID(ctx context.Context, in *GetMatchByIDRequest, opts ...grpc.CallOption) (*GetMatchByIDResponse, error) {
+	out := new(GetMatchByIDResponse)
+	err := c.cc.Invoke(ctx, "/sports.Sports/GetMatchByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) SearchMatches(ctx context.Context, in *SearchMatchesRequest, opts ...grpc.CallOption) (*SearchMatchesResponse, error) {
+	out := new(SearchMatchesResponse)
+	err := c.cc.Invoke(ctx, "/sports.Sports/SearchMatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SportsServer is the server API for Sports service.
+// All implementations must embed UnimplementedSportsServer
+// for forward compatibility
+type SportsServer interface {
+	// ListEvents returns a collection of sports events. Filters are passed as
+	// query parameters, e.g. ?filter.sport=AFL&filter.start_time_from=2021-03-02T00:00:00Z
+	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	// GetEvent returns a single event by its ID.
+	GetEvent(context.Context, *GetEventRequest) (*Event, error)
+	// Deprecated: Do not use.
+	// ListMatches returns a collection of all matches. Filters are passed as
+	// query parameters, e.g. ?filter.sport=AFL&filter.start_time_from=2021-03-02T00:00:00Z
+	// Deprecated: use ListEvents. Kept while clients migrate.
+	ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error)
+	// Deprecated: Do not use.
+	// GetMatchByID returns a single match by its ID.
+	// Deprecated: use GetEvent. Kept while clients migrate.
+	GetMatchByID(context.Context, *GetMatchByIDRequest) (*GetMatchByIDResponse, error)
+	// SearchMatches returns the matches whose name or teams contain every word
+	// of a query, best matches first, with the matching words highlighted. It
+	// needs the service built with SQLite FTS5 and otherwise fails with
+	// UNIMPLEMENTED. Like GetMatchByID it has no REST route.
+	SearchMatches(context.Context, *SearchMatchesRequest) (*SearchMatchesResponse, error)
+	mustEmbedUnimplementedSportsServer()
+}
+
+// UnimplementedSportsServer must be embedded to have forward compatible implementations.
+type UnimplementedSportsServer struct {
+}
+
+func (UnimplementedSportsServer) ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
+}
+func (UnimplementedSportsServer) GetEvent(context.Context, *GetEventRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvent not implemented")
+}
+func (UnimplementedSportsServer) ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
+}
+func (UnimplementedSportsServer) GetMatchByID(context.Context, *GetMatchByIDRequest) (*GetMatchByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatchByID not implemented")
+}
+func (UnimplementedSportsServer) SearchMatches(context.Context, *SearchMatchesRequest) (*SearchMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMatches not implemented")
+}
+func (UnimplementedSportsServer) mustEmbedUnimplementedSportsServer() {}
+
+// UnsafeSportsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SportsServer will
+// result in compilation errors.
+type UnsafeSportsServer interface {
+	mustEmbedUnimplementedSportsServer()
+}
+
+func RegisterSportsServer(s grpc.ServiceRegistrar, srv SportsServer) {
+	s.RegisterService(&Sports_ServiceDesc, srv)
+}
+
+func _Sports_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).ListEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sports.Sports/ListEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).ListEvents(ctx, req.(*ListEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).GetEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sports.Sports/GetEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).GetEvent(ctx, req.(*GetEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_ListMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).ListMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sports.Sports/ListMatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).ListMatches(ctx, req.(*ListMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_GetMatchByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMatchByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).GetMatchByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sports.Sports/GetMatchByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).GetMatchByID(ctx, req.(*GetMatchByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_SearchMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).SearchMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sports.Sports/SearchMatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).SearchMatches(ctx, req.(*SearchMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Sports_ServiceDesc is the grpc.ServiceDesc for Sports service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Sports_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sports.Sports",
+	HandlerType: (*SportsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListEvents",
+			Handler:    _Sports_ListEvents_Handler,
+		},
+		{
+			MethodName: "GetEvent",
+			Handler:    _Sports_GetEvent_Handler,
+		},
+		{
+			MethodName: "ListMatches",
+			Handler:    _Sports_ListMatches_Handler,
+		},
+		{
+			MethodName: "GetMatchByID",
+			Handler:    _Sports_GetMatchByID_Handler,
+		},
+		{
+			MethodName: "SearchMatches",
+			Handler:    _Sports_SearchMatches_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sports/sports.proto",
+}
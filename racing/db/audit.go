@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/Kim-Hardie/entain-master/storage"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// auditOrder is the order the visibility audit is listed in, newest first.
+// Entry ids only ever increase, so they order entries by when they were made.
+var auditOrder = []orderTerm{
+	{field: sortField{column: "id"}, descending: true},
+}
+
+func (r *racesRepo) SetVisibility(ctx context.Context, ids []int64, visible bool, actor, reason string) ([]*racing.Race, []*racing.Race, error) {
+	// Serializable, so concurrent changes to the same race can't both be
+	// recorded as changes on PostgreSQL. SQLite transactions are always
+	// serializable.
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, nil, storage.Error(ctx, err)
+	}
+	defer tx.Rollback()
+
+	now := r.now()
+	queries := getRaceQueries(r.dialect)
+
+	before, err := r.getByIDs(ctx, tx, ids, now)
+	if err != nil {
+		return nil, nil, storage.Error(ctx, err)
+	}
+
+	var missing []int64
+	for i, id := range ids {
+		if before[i] == nil {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("races %v: %w", missing, ErrNotFound)
+	}
+
+	for _, race := range before {
+		// Races already as asked are left alone, so the audit only holds changes.
+		if race.Visible == visible {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, r.dialect.Rebind(queries[racesSetVisible]), visible, race.Id); err != nil {
+			return nil, nil, storage.Error(ctx, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, r.dialect.Rebind(queries[auditInsert]), race.Id, visible, actor, reason, now.UTC()); err != nil {
+			return nil, nil, storage.Error(ctx, err)
+		}
+	}
+
+	races, err := r.getByIDs(ctx, tx, ids, now)
+	if err != nil {
+		return nil, nil, storage.Error(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, storage.Error(ctx, err)
+	}
+
+	return before, races, nil
+}
+
+func (r *racesRepo) ListAudit(ctx context.Context, filter *racing.ListRaceAuditRequestFilter, pageSize int32, pageToken string) ([]*racing.RaceAuditEntry, string, error) {
+	var (
+		clauses []string
+		args    []interface{}
+	)
+
+	fingerprint := queryFingerprint(filter, "")
+
+	if len(filter.GetRaceIds()) > 0 {
+		clauses = append(clauses, "race_id IN ("+strings.Repeat("?,", len(filter.RaceIds)-1)+"?)")
+
+		for _, raceID := range filter.RaceIds {
+			args = append(args, raceID)
+		}
+	}
+
+	if filter.GetActor() != "" {
+		clauses = append(clauses, "actor = ?")
+		args = append(args, filter.Actor)
+	}
+
+	if pageToken != "" {
+		cursor, err := decodePageCursor(pageToken, auditOrder, fingerprint)
+		if err != nil {
+			return nil, "", err
+		}
+
+		clause, cursorArgs := seekClause(auditOrder, cursor.Values, r.dialect)
+		clauses = append(clauses, clause)
+		args = append(args, cursorArgs...)
+	}
+
+	query := getRaceQueries(r.dialect)[auditList]
+	if len(clauses) != 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+	query += orderClause(auditOrder, r.dialect)
+
+	// Fetch one extra row so we know whether there is another page.
+	query += " LIMIT ?"
+	args = append(args, pageSize+1)
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, "", storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	entries, err := scanAuditEntries(rows)
+	if err != nil {
+		return nil, "", storage.Error(ctx, err)
+	}
+
+	if len(entries) <= int(pageSize) {
+		return entries, "", nil
+	}
+
+	entries = entries[:pageSize]
+	nextPageToken, err := encodePageCursor([]interface{}{entries[len(entries)-1].Id}, fingerprint)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return entries, nextPageToken, nil
+}
+
+// scanAuditEntries scans the database rows and converts them to audit entries.
+func scanAuditEntries(rows *sql.Rows) ([]*racing.RaceAuditEntry, error) {
+	var entries []*racing.RaceAuditEntry
+
+	for rows.Next() {
+		var (
+			entry     racing.RaceAuditEntry
+			changedAt time.Time
+		)
+
+		if err := rows.Scan(&entry.Id, &entry.RaceId, &entry.Visible, &entry.Actor, &entry.Reason, &changedAt); err != nil {
+			return nil, err
+		}
+
+		entry.ChangedAt = timestamppb.New(changedAt)
+
+		entries = append(entries, &entry)
+	}
+
+	return entries, rows.Err()
+}
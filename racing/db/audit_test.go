@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRacesRepo_SetVisibility(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	frozen := time.Now().UTC().Truncate(time.Second)
+	repo.now = func() time.Time { return frozen }
+
+	_, err := repo.db.Exec(repo.dialect.Rebind(`UPDATE races SET visible = ? WHERE id IN (1, 2)`), true)
+	require.NoError(t, err)
+	_, err = repo.db.Exec(repo.dialect.Rebind(`UPDATE races SET visible = ? WHERE id = 3`), false)
+	require.NoError(t, err)
+
+	before, races, err := repo.SetVisibility(context.Background(), []int64{3, 1, 2}, false, "jo", "scratched meeting")
+	require.NoError(t, err)
+	require.Len(t, before, 3)
+	require.Len(t, races, 3)
+	for i, id := range []int64{3, 1, 2} {
+		assert.Equal(t, id, before[i].Id)
+		assert.Equal(t, id != 3, before[i].Visible)
+		assert.Equal(t, id, races[i].Id)
+		assert.False(t, races[i].Visible)
+	}
+
+	race, err := repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.False(t, race.Visible)
+
+	// Race 3 was already hidden, so only races 1 and 2 changed.
+	entries, next, err := repo.ListAudit(context.Background(), nil, 10, "")
+	require.NoError(t, err)
+	assert.Empty(t, next)
+	require.Len(t, entries, 2)
+	assert.Equal(t, int64(2), entries[0].RaceId)
+	assert.Equal(t, int64(1), entries[1].RaceId)
+	for _, entry := range entries {
+		assert.False(t, entry.Visible)
+		assert.Equal(t, "jo", entry.Actor)
+		assert.Equal(t, "scratched meeting", entry.Reason)
+		assert.Equal(t, frozen, entry.ChangedAt.AsTime())
+	}
+}
+
+// A batch naming an unknown race changes nothing.
+func TestRacesRepo_SetVisibilityNotFound(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	before, err := repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	_, _, err = repo.SetVisibility(context.Background(), []int64{1, 1000, 1001}, !before.Visible, "jo", "typo")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "[1000 1001]")
+
+	after, err := repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, before.Visible, after.Visible)
+
+	entries, _, err := repo.ListAudit(context.Background(), nil, 10, "")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRacesRepo_ListAudit(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	for i, actor := range []string{"jo", "sam", "jo", "jo"} {
+		_, _, err := repo.SetVisibility(context.Background(), []int64{1, 2}, i%2 == 0, actor, "testing")
+		require.NoError(t, err)
+	}
+
+	// Every entry once, newest first, whatever the page size.
+	var all []*racing.RaceAuditEntry
+	var token string
+	for {
+		page, next, err := repo.ListAudit(context.Background(), nil, 3, token)
+		require.NoError(t, err)
+		all = append(all, page...)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	require.NotEmpty(t, all)
+	for i := 1; i < len(all); i++ {
+		assert.Greater(t, all[i-1].Id, all[i].Id)
+	}
+
+	filter := &racing.ListRaceAuditRequestFilter{RaceIds: []int64{2}, Actor: "sam"}
+	entries, _, err := repo.ListAudit(context.Background(), filter, 10, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(2), entries[0].RaceId)
+	assert.Equal(t, "sam", entries[0].Actor)
+
+	// Tokens are tied to the filter they were issued for.
+	_, next, err := repo.ListAudit(context.Background(), nil, 1, "")
+	require.NoError(t, err)
+	_, _, err = repo.ListAudit(context.Background(), filter, 1, next)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
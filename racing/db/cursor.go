@@ -0,0 +1,99 @@
+package db
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrInvalidPageToken is returned when a page token cannot be decoded, or was
+// issued for a different filter or ordering than the one it is used with. It
+// is an ErrInvalidFilter.
+var ErrInvalidPageToken = fmt.Errorf("%w: bad page token", ErrInvalidFilter)
+
+// pageCursor marks the last race or meeting of a page. The next page starts
+// strictly after it in the requested ordering.
+type pageCursor struct {
+	// Values holds the last item's value for each term of the ordering.
+	Values []interface{} `json:"v"`
+	// Query is a fingerprint of the filter and ordering the cursor was
+	// issued for.
+	Query uint32 `json:"q"`
+}
+
+// encodeRaceCursor returns an opaque page token pointing after race.
+func encodeRaceCursor(race *racing.Race, terms []orderTerm, fingerprint uint32) (string, error) {
+	values := make([]interface{}, 0, len(terms))
+	for _, term := range terms {
+		values = append(values, term.field.value(race))
+	}
+
+	return encodePageCursor(values, fingerprint)
+}
+
+// encodePageCursor returns an opaque page token pointing after the item with
+// the given values for each term of the ordering.
+func encodePageCursor(values []interface{}, fingerprint uint32) (string, error) {
+	b, err := json.Marshal(pageCursor{Values: values, Query: fingerprint})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodePageCursor parses a page token and checks it belongs to the query
+// identified by fingerprint, ordered by terms.
+func decodePageCursor(token string, terms []orderTerm, fingerprint uint32) (*pageCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var cursor pageCursor
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cursor); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	if cursor.Query != fingerprint || len(cursor.Values) != len(terms) {
+		return nil, ErrInvalidPageToken
+	}
+
+	// Numbers come back as json.Number; every numeric sort field is an
+	// integer, so bind them as such.
+	for i, value := range cursor.Values {
+		if number, ok := value.(json.Number); ok {
+			if cursor.Values[i], err = number.Int64(); err != nil {
+				return nil, ErrInvalidPageToken
+			}
+		}
+	}
+
+	return &cursor, nil
+}
+
+// queryFingerprint hashes the filter and ordering so a token can't be replayed
+// against a different result set.
+func queryFingerprint(filter proto.Message, orderBy string) uint32 {
+	h := fnv.New32a()
+	// A nil filter marshals to nothing, like an empty one, but doesn't always
+	// select the same races: ListRaces only defaults to visible races when
+	// given a filter. So whether there is one is hashed too.
+	if filter.ProtoReflect().IsValid() {
+		h.Write([]byte{1})
+	} else {
+		h.Write([]byte{0})
+	}
+	b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(filter)
+	h.Write(b)
+	h.Write([]byte(orderBy))
+
+	return h.Sum32()
+}
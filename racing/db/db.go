@@ -1,37 +1,203 @@
 package db
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
 	"time"
 
+	"github.com/Kim-Hardie/entain-master/proto/racing"
 	"syreclabs.com/go/faker"
 )
 
+// seedVenues are the tracks dummy meetings are held at, by race type.
+var seedVenues = map[racing.RaceType][]string{
+	racing.RaceType_THOROUGHBRED: {"Flemington", "Randwick", "Eagle Farm", "Morphettville"},
+	racing.RaceType_HARNESS:      {"Menangle", "Melton", "Albion Park"},
+	racing.RaceType_GREYHOUND:    {"Sandown Park", "Wentworth Park", "The Meadows"},
+}
+
+// seed gives an empty database dummy races. A database that already has
+// races is left alone, so races deleted since aren't brought back and races
+// created since don't clash with seeded ones.
 func (r *racesRepo) seed() error {
-	statement, err := r.db.Prepare(`CREATE TABLE IF NOT EXISTS races (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, status TEXT)`)
-	if err == nil {
-		_, err = statement.Exec()
+	// Meetings come first, as races reference them.
+	if err := r.seedMeetings(); err != nil {
+		return err
+	}
+
+	var count int
+	if err := r.db.QueryRow(`SELECT count(*) FROM races`).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		if err := r.seedRaces(); err != nil {
+			return err
+		}
 	}
 
+	// A race takes its category from its meeting, which may only just have
+	// been given a race type if it was a placeholder.
+	_, err := r.db.Exec(`
+		UPDATE races SET category = (SELECT race_type FROM meetings WHERE meetings.id = races.meeting_id)
+		WHERE category = 'RACE_TYPE_UNSPECIFIED' AND meeting_id IN (SELECT id FROM meetings)
+	`)
+
+	return err
+}
+
+// seedRaces seeds the dummy races and their runners, in one transaction. The
+// database gives the races their ids, so none is a deleted race's.
+func (r *racesRepo) seedRaces() error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Races start OPEN and read as CLOSED once they jump, so status isn't seeded.
+	query := `INSERT INTO races(meeting_id, name, number, visible, advertised_start_time) VALUES (?,?,?,?,?)`
+
+	// seeded maps each seeded race's id to its meeting's id.
+	seeded := make(map[int64]int64, 100)
+
 	for i := 1; i <= 100; i++ {
 		advertisedStartTime := faker.Time().Between(time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 2))
-		status := "OPEN"
-		if advertisedStartTime.Before(time.Now()) {
-			status = "CLOSED"
+
+		// Each meeting gets races numbered 1 to 10, as a meeting's race
+		// numbers must be unique.
+		meetingID := int64((i-1)%10 + 1)
+		raceID, err := r.insertRace(context.Background(), tx, query,
+			meetingID,
+			faker.Team().Name(),
+			(i-1)/10+1,
+			faker.Number().Between(0, 1),
+			advertisedStartTime.Format(time.RFC3339),
+		)
+		if err != nil {
+			return err
+		}
+		seeded[raceID] = meetingID
+	}
+
+	if err := r.seedRunners(tx, seeded); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// seedMeetings seeds the meetings races are given. Meetings created as
+// placeholders when the meetings table was added have no venue, so they are
+// filled in too.
+func (r *racesRepo) seedMeetings() error {
+	statement, err := r.db.Prepare(r.dialect.Rebind(`
+		INSERT INTO meetings(id, venue, country, race_type, date, track_condition, weather) VALUES (?,?,?,?,?,?,?)
+		ON CONFLICT (id) DO UPDATE SET
+			venue = excluded.venue,
+			country = excluded.country,
+			race_type = excluded.race_type,
+			track_condition = excluded.track_condition,
+			weather = excluded.weather
+		WHERE meetings.venue = ''
+	`))
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	raceTypes := []racing.RaceType{racing.RaceType_THOROUGHBRED, racing.RaceType_HARNESS, racing.RaceType_GREYHOUND}
+
+	for i := 1; i <= 10; i++ {
+		raceType := raceTypes[faker.RandomInt(0, len(raceTypes)-1)]
+
+		_, err = statement.Exec(
+			i,
+			faker.RandomChoice(seedVenues[raceType]),
+			"AU",
+			raceType.String(),
+			faker.Time().Between(time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 2)).Format(dateLayout),
+			faker.RandomChoice([]string{"Firm 2", "Good 4", "Soft 5", "Soft 7", "Heavy 8"}),
+			faker.RandomChoice([]string{"Fine", "Overcast", "Showers"}),
+		)
+		if err != nil {
+			return err
 		}
+	}
+
+	return nil
+}
+
+// seedRunners gives each seeded race, given as a map of race id to meeting
+// id, a field suited to its meeting's race type. Races created through the
+// service are left for their own runners.
+func (r *racesRepo) seedRunners(tx *sql.Tx, seeded map[int64]int64) error {
+	rows, err := tx.Query(`SELECT id, race_type FROM meetings`)
+	if err != nil {
+		return err
+	}
+
+	meetingTypes := make(map[int64]racing.RaceType)
+	for rows.Next() {
+		var (
+			meetingID int64
+			raceType  string
+		)
+		if err := rows.Scan(&meetingID, &raceType); err != nil {
+			rows.Close()
+			return err
+		}
+		meetingTypes[meetingID] = racing.RaceType(racing.RaceType_value[raceType])
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	statement, err := tx.Prepare(r.dialect.Rebind(`INSERT INTO runners(race_id, number, name, barrier, jockey, trainer, weight, scratched, silks_url) VALUES (?,?,?,?,?,?,?,?,?) ON CONFLICT DO NOTHING`))
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	for raceID, meetingID := range seeded {
+		raceType := meetingTypes[meetingID]
+		field := faker.RandomInt(8, 12)
+		if raceType == racing.RaceType_GREYHOUND {
+			field = 8
+		}
+		barriers := rand.Perm(field)
+
+		for number := 1; number <= field; number++ {
+			var (
+				jockey, silksURL string
+				weight           float64
+			)
+			if raceType != racing.RaceType_GREYHOUND {
+				jockey = faker.Name().Name()
+				silksURL = fmt.Sprintf("https://silks.example.com/%d/%d.png", raceID, number)
+			}
+			if raceType == racing.RaceType_THOROUGHBRED {
+				weight = float64(faker.RandomInt(108, 120)) / 2
+			}
 
-		statement, err = r.db.Prepare(`INSERT OR IGNORE INTO races(id, meeting_id, name, number, visible, advertised_start_time, status) VALUES (?,?,?,?,?,?,?)`)
-		if err == nil {
 			_, err = statement.Exec(
-				i,
-				faker.Number().Between(1, 10),
-				faker.Team().Name(),
-				faker.Number().Between(1, 12),
-				faker.Number().Between(0, 1),
-				advertisedStartTime.Format(time.RFC3339),
-				status,
+				raceID,
+				number,
+				faker.App().Name(),
+				barriers[number-1]+1,
+				jockey,
+				faker.Name().Name(),
+				weight,
+				faker.RandomInt(1, 10) == 1,
+				silksURL,
 			)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
-	return err
+	return nil
 }
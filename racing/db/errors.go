@@ -0,0 +1,38 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/Kim-Hardie/entain-master/storage"
+)
+
+var (
+	// ErrNotFound is returned when the requested race doesn't exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrInvalidFilter is returned when a list can't be run as asked, e.g. a
+	// malformed order_by or page token.
+	ErrInvalidFilter = errors.New("invalid filter")
+
+	// ErrAlreadyExists is returned when a race would share its meeting and
+	// number with another race.
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrInvalidRace is returned when a race can't be written as given, e.g.
+	// its meeting doesn't exist.
+	ErrInvalidRace = errors.New("invalid race")
+
+	// ErrRaceHasChildren is returned when deleting a race that still has
+	// runners or a result without forcing it.
+	ErrRaceHasChildren = errors.New("race has runners or a result")
+
+	// ErrSearchUnavailable is returned when races can't be searched, as the
+	// database is PostgreSQL or SQLite built without FTS5.
+	ErrSearchUnavailable = errors.New("search unavailable")
+
+	// ErrUnavailable is returned when the database can't currently serve the
+	// request, e.g. it is locked, can't be opened or can't be reached.
+	// Retrying may succeed. It is storage.ErrUnavailable, which errors from
+	// storage.Error wrap.
+	ErrUnavailable = storage.ErrUnavailable
+)
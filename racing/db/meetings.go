@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/Kim-Hardie/entain-master/storage"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+)
+
+// dateLayout is how meeting dates are formatted, e.g. 2021-03-02.
+const dateLayout = "2006-01-02"
+
+// meetingOrder is the order meetings are listed in. Meetings have no order_by,
+// so page cursors always hold a date and an id.
+var meetingOrder = []orderTerm{
+	{field: sortField{column: "date"}},
+	{field: sortField{column: "id"}},
+}
+
+// MeetingsRepo provides repository access to meetings. The meetings schema and
+// dummy data are set up by RacesRepo.Init, as races reference meetings.
+type MeetingsRepo interface {
+	// List will return a page of meetings ordered by date, along with a
+	// token for the next page if there is one.
+	List(ctx context.Context, filter *racing.ListMeetingsRequestFilter, pageSize int32, pageToken string) ([]*racing.Meeting, string, error)
+
+	// GetByID will return a meeting by its ID, or ErrNotFound if there is none.
+	GetByID(ctx context.Context, id int64) (*racing.Meeting, error)
+}
+
+type meetingsRepo struct {
+	db      *sql.DB
+	dialect dialect.Dialect
+}
+
+// NewMeetingsRepo creates a new meetings repository.
+func NewMeetingsRepo(db *sql.DB) MeetingsRepo {
+	return &meetingsRepo{db: db, dialect: dialect.Of(db)}
+}
+
+func (r *meetingsRepo) List(ctx context.Context, filter *racing.ListMeetingsRequestFilter, pageSize int32, pageToken string) ([]*racing.Meeting, string, error) {
+	var (
+		clauses []string
+		args    []interface{}
+	)
+
+	fingerprint := queryFingerprint(filter, "")
+
+	if len(filter.GetRaceTypes()) > 0 {
+		clauses = append(clauses, "race_type IN ("+strings.Repeat("?,", len(filter.RaceTypes)-1)+"?)")
+
+		for _, raceType := range filter.RaceTypes {
+			args = append(args, raceType.String())
+		}
+	}
+
+	// Dates are stored as YYYY-MM-DD, so they compare as text on SQLite.
+	if filter.GetDateFrom() != "" {
+		clauses = append(clauses, "date >= ?")
+		args = append(args, filter.DateFrom)
+	}
+
+	if filter.GetDateTo() != "" {
+		clauses = append(clauses, "date < ?")
+		args = append(args, filter.DateTo)
+	}
+
+	if pageToken != "" {
+		cursor, err := decodePageCursor(pageToken, meetingOrder, fingerprint)
+		if err != nil {
+			return nil, "", err
+		}
+
+		clause, cursorArgs := seekClause(meetingOrder, cursor.Values, r.dialect)
+		clauses = append(clauses, clause)
+		args = append(args, cursorArgs...)
+	}
+
+	query := getRaceQueries(r.dialect)[meetingsList]
+	if len(clauses) != 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+	query += orderClause(meetingOrder, r.dialect)
+
+	// Fetch one extra row so we know whether there is another page.
+	query += " LIMIT ?"
+	args = append(args, pageSize+1)
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, "", storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	meetings, err := scanMeetings(rows)
+	if err != nil {
+		return nil, "", storage.Error(ctx, err)
+	}
+
+	if len(meetings) <= int(pageSize) {
+		return meetings, "", nil
+	}
+
+	meetings = meetings[:pageSize]
+	last := meetings[len(meetings)-1]
+	nextPageToken, err := encodePageCursor([]interface{}{last.Date, last.Id}, fingerprint)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return meetings, nextPageToken, nil
+}
+
+func (r *meetingsRepo) GetByID(ctx context.Context, meetingID int64) (*racing.Meeting, error) {
+	query := r.dialect.Rebind(getRaceQueries(r.dialect)[meetingsList] + " WHERE id = ?")
+
+	rows, err := r.db.QueryContext(ctx, query, meetingID)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	meetings, err := scanMeetings(rows)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	if len(meetings) == 0 {
+		return nil, fmt.Errorf("meeting %d: %w", meetingID, ErrNotFound)
+	}
+
+	return meetings[0], nil
+}
+
+// scanMeetings scans the database rows and converts them to meetings.
+func scanMeetings(rows *sql.Rows) ([]*racing.Meeting, error) {
+	var meetings []*racing.Meeting
+
+	for rows.Next() {
+		var (
+			meeting  racing.Meeting
+			raceType string
+			date     time.Time
+		)
+
+		if err := rows.Scan(&meeting.Id, &meeting.Venue, &meeting.Country, &raceType, &date, &meeting.TrackCondition, &meeting.Weather); err != nil {
+			return nil, err
+		}
+
+		meeting.RaceType = racing.RaceType(racing.RaceType_value[raceType])
+		meeting.Date = date.Format(dateLayout)
+
+		meetings = append(meetings, &meeting)
+	}
+
+	return meetings, rows.Err()
+}
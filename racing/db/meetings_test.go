@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMeetingsRepo returns a meetings repository backed by a freshly seeded database.
+func newTestMeetingsRepo(t *testing.T) *meetingsRepo {
+	t.Helper()
+
+	repo := newTestRacesRepo(t)
+
+	return NewMeetingsRepo(repo.db).(*meetingsRepo)
+}
+
+// Paging through meetings visits every meeting exactly once, ordered by date.
+func TestMeetingsRepo_ListPagination(t *testing.T) {
+	repo := newTestMeetingsRepo(t)
+
+	all, next, err := repo.List(context.Background(), nil, 1000, "")
+	require.NoError(t, err)
+	assert.Empty(t, next)
+	require.Len(t, all, 10)
+
+	var paged []*racing.Meeting
+	token := ""
+	for {
+		page, next, err := repo.List(context.Background(), nil, 3, token)
+		require.NoError(t, err)
+		paged = append(paged, page...)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	assert.Equal(t, all, paged)
+	for i := 1; i < len(all); i++ {
+		assert.LessOrEqual(t, all[i-1].Date, all[i].Date)
+	}
+
+	_, _, err = repo.List(context.Background(), &racing.ListMeetingsRequestFilter{DateFrom: "2000-01-01"}, 3, token)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestMeetingsRepo_ListFilter(t *testing.T) {
+	repo := newTestMeetingsRepo(t)
+
+	all, _, err := repo.List(context.Background(), nil, 1000, "")
+	require.NoError(t, err)
+
+	first := all[0]
+	filter := &racing.ListMeetingsRequestFilter{
+		RaceTypes: []racing.RaceType{first.RaceType},
+		DateFrom:  first.Date,
+	}
+
+	meetings, _, err := repo.List(context.Background(), filter, 1000, "")
+	require.NoError(t, err)
+	assert.Contains(t, meetings, first)
+	for _, meeting := range meetings {
+		assert.Equal(t, first.RaceType, meeting.RaceType)
+		assert.GreaterOrEqual(t, meeting.Date, first.Date)
+	}
+
+	// date_to is exclusive.
+	filter.DateTo = first.Date
+	meetings, _, err = repo.List(context.Background(), filter, 1000, "")
+	require.NoError(t, err)
+	assert.Empty(t, meetings)
+}
+
+func TestMeetingsRepo_GetByID(t *testing.T) {
+	repo := newTestMeetingsRepo(t)
+
+	meeting, err := repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), meeting.Id)
+	assert.NotEmpty(t, meeting.Venue)
+	assert.Equal(t, "AU", meeting.Country)
+	assert.NotEqual(t, racing.RaceType_RACE_TYPE_UNSPECIFIED, meeting.RaceType)
+
+	_, err = repo.GetByID(context.Background(), 1000)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// Races only carry their meeting when asked for.
+func TestRacesRepo_ListIncludeMeeting(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	races, _, err := repo.List(context.Background(), nil, ListOptions{PageSize: 1000})
+	require.NoError(t, err)
+	require.NotEmpty(t, races)
+	for _, race := range races {
+		assert.Nil(t, race.Meeting)
+	}
+
+	races, _, err = repo.List(context.Background(), nil, ListOptions{PageSize: 1000, IncludeMeeting: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, races)
+	for _, race := range races {
+		require.NotNil(t, race.Meeting, "race %d", race.Id)
+		assert.Equal(t, race.MeetingId, race.Meeting.Id)
+		assert.NotEmpty(t, race.Meeting.Venue)
+	}
+}
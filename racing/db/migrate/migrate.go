@@ -0,0 +1,324 @@
+// Package migrate applies versioned schema migrations to a SQLite or
+// PostgreSQL database.
+//
+// Migrations are pairs of SQL files named NNNN_name.up.sql and
+// NNNN_name.down.sql. Applied migrations are recorded in a schema_migrations
+// table along with a checksum of their up SQL, so a migration edited after it
+// has been applied is caught rather than silently diverging.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+)
+
+var (
+	// ErrChecksumMismatch is returned when an applied migration's SQL no
+	// longer matches what was applied.
+	ErrChecksumMismatch = errors.New("migration checksum mismatch")
+
+	// ErrUnknownVersion is returned when the database has a migration applied
+	// that this binary doesn't know about, e.g. after rolling back a deploy.
+	ErrUnknownVersion = errors.New("unknown migration applied")
+)
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	Version int
+	Name    string
+	// Up applies the migration and Down reverts it.
+	Up, Down string
+	// Skip, if set, is checked before Up runs. When it reports true the
+	// migration is recorded as applied without running Up, for changes some
+	// existing databases were given by hand.
+	Skip func(ctx context.Context, tx *sql.Tx) (bool, error)
+}
+
+// Checksum identifies the migration's up SQL.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Status describes whether a migration has been applied.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load reads migrations from the SQL files at the root of fsys, ordered by
+// version. Every version must have both an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+
+		b, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files named both %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(b)
+		} else {
+			migration.Down = string(b)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %s needs both an up and a down file", migration)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Migrator applies and reverts migrations against a database.
+type Migrator struct {
+	db         *sql.DB
+	dialect    dialect.Dialect
+	migrations []Migration
+
+	// DryRun logs the SQL each migration would run instead of running it.
+	DryRun bool
+	// Logf reports each migration applied or reverted. Defaults to log.Printf.
+	Logf func(format string, args ...interface{})
+}
+
+// New creates a Migrator for migrations, which must be ordered by version.
+func New(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, dialect: dialect.Of(db), migrations: migrations, Logf: log.Printf}
+}
+
+// Up applies every pending migration in order, each in its own transaction.
+func (m *Migrator) Up(ctx context.Context) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err := m.apply(ctx, migration); err != nil {
+			return fmt.Errorf("applying migration %s: %w", migration, err)
+		}
+	}
+
+	return nil
+}
+
+// Down reverts the steps most recently applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		if err := m.revert(ctx, migration); err != nil {
+			return fmt.Errorf("reverting migration %s: %w", migration, err)
+		}
+		steps--
+	}
+
+	return nil
+}
+
+// Status lists every migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses = append(statuses, Status{Migration: migration, Applied: ok, AppliedAt: appliedAt})
+	}
+
+	return statuses, nil
+}
+
+// applied returns when each applied migration was applied, after checking
+// they are all known and unchanged.
+func (m *Migrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	// A database that has never been migrated has no schema_migrations
+	// table yet. It's only created once a migration is applied, so a dry run
+	// leaves the database untouched.
+	var tables int
+	if err := m.db.QueryRowContext(ctx, m.dialect.Rebind(m.dialect.TableExistsQuery()), "schema_migrations").Scan(&tables); err != nil {
+		return nil, err
+	}
+	if tables == 0 {
+		return map[int]time.Time{}, nil
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	known := make(map[int]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var (
+			version   int
+			checksum  string
+			appliedAt time.Time
+		)
+		if err := rows.Scan(&version, &checksum, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		migration, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: version %d", ErrUnknownVersion, version)
+		}
+		if migration.Checksum() != checksum {
+			return nil, fmt.Errorf("%w: %s was changed after being applied", ErrChecksumMismatch, migration)
+		}
+
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+func (m *Migrator) apply(ctx context.Context, migration Migration) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	skip := false
+	if migration.Skip != nil {
+		if skip, err = migration.Skip(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	if m.DryRun {
+		if skip {
+			m.Logf("would record migration %s as applied; the database already has it", migration)
+		} else {
+			m.Logf("would apply migration %s:\n%s", migration, migration.Up)
+		}
+		return nil
+	}
+
+	if !skip {
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY, name TEXT NOT NULL, checksum TEXT NOT NULL, applied_at `+m.dialect.TimestampType()+` NOT NULL)`); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, m.dialect.Rebind(`INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`),
+		migration.Version, migration.Name, migration.Checksum(), time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if skip {
+		m.Logf("recorded migration %s as applied; the database already has it", migration)
+	} else {
+		m.Logf("applied migration %s", migration)
+	}
+
+	return nil
+}
+
+func (m *Migrator) revert(ctx context.Context, migration Migration) error {
+	if m.DryRun {
+		m.Logf("would revert migration %s:\n%s", migration, migration.Down)
+		return nil
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, m.dialect.Rebind(`DELETE FROM schema_migrations WHERE version = ?`), migration.Version); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	m.Logf("reverted migration %s", migration)
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFiles = fstest.MapFS{
+	"0001_create_things.up.sql":   {Data: []byte(`CREATE TABLE things (id INTEGER PRIMARY KEY);`)},
+	"0001_create_things.down.sql": {Data: []byte(`DROP TABLE things;`)},
+	"0002_add_colour.up.sql":      {Data: []byte(`ALTER TABLE things ADD COLUMN colour TEXT;`)},
+	"0002_add_colour.down.sql":    {Data: []byte(`CREATE TABLE things_old (id INTEGER PRIMARY KEY); INSERT INTO things_old SELECT id FROM things; DROP TABLE things; ALTER TABLE things_old RENAME TO things;`)},
+	"README.md":                   {Data: []byte(`not a migration`)},
+}
+
+// newTestMigrator returns a migrator for the test migrations against an empty database.
+func newTestMigrator(t *testing.T, migrations []Migration) (*Migrator, *sql.DB) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migrator := New(db, migrations)
+	migrator.Logf = t.Logf
+
+	return migrator, db
+}
+
+func loadTestMigrations(t *testing.T) []Migration {
+	t.Helper()
+
+	migrations, err := Load(testFiles)
+	require.NoError(t, err)
+
+	return migrations
+}
+
+// columns lists the columns of table, or nothing if it doesn't exist.
+func columns(t *testing.T, db *sql.DB, table string) []string {
+	t.Helper()
+
+	rows, err := db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func TestLoad(t *testing.T) {
+	migrations := loadTestMigrations(t)
+
+	require.Len(t, migrations, 2)
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "create_things", migrations[0].Name)
+	assert.Equal(t, "0002_add_colour", migrations[1].String())
+
+	_, err := Load(fstest.MapFS{"0001_create_things.up.sql": {Data: []byte(`SELECT 1;`)}})
+	assert.Error(t, err, "a migration without a down file")
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	ctx := context.Background()
+	migrator, db := newTestMigrator(t, loadTestMigrations(t))
+
+	require.NoError(t, migrator.Up(ctx))
+	assert.Equal(t, []string{"id", "colour"}, columns(t, db, "things"))
+
+	// Up is a no-op once everything is applied.
+	require.NoError(t, migrator.Up(ctx))
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Applied, status.Migration.String())
+	}
+
+	require.NoError(t, migrator.Down(ctx, 1))
+	assert.Equal(t, []string{"id"}, columns(t, db, "things"))
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+
+	require.NoError(t, migrator.Down(ctx, 5))
+	assert.Empty(t, columns(t, db, "things"))
+}
+
+func TestMigrator_DryRun(t *testing.T) {
+	ctx := context.Background()
+	migrator, db := newTestMigrator(t, loadTestMigrations(t))
+
+	migrator.DryRun = true
+	require.NoError(t, migrator.Up(ctx))
+	assert.Empty(t, columns(t, db, "things"))
+	assert.Empty(t, columns(t, db, "schema_migrations"), "a dry run leaves the database untouched")
+
+	migrator.DryRun = false
+	require.NoError(t, migrator.Up(ctx))
+
+	migrator.DryRun = true
+	require.NoError(t, migrator.Down(ctx, 2))
+	assert.Equal(t, []string{"id", "colour"}, columns(t, db, "things"))
+}
+
+func TestMigrator_Skip(t *testing.T) {
+	ctx := context.Background()
+	migrations := loadTestMigrations(t)
+	migrations[1].Skip = func(ctx context.Context, tx *sql.Tx) (bool, error) { return true, nil }
+
+	migrator, db := newTestMigrator(t, migrations)
+	require.NoError(t, migrator.Up(ctx))
+
+	// The skipped migration is recorded without running.
+	assert.Equal(t, []string{"id"}, columns(t, db, "things"))
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[1].Applied)
+}
+
+func TestMigrator_ChecksumMismatch(t *testing.T) {
+	ctx := context.Background()
+	migrator, db := newTestMigrator(t, loadTestMigrations(t))
+	require.NoError(t, migrator.Up(ctx))
+
+	edited := loadTestMigrations(t)
+	edited[0].Up = `CREATE TABLE things (id INTEGER PRIMARY KEY, name TEXT);`
+
+	err := New(db, edited).Up(ctx)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestMigrator_UnknownVersion(t *testing.T) {
+	ctx := context.Background()
+	migrator, db := newTestMigrator(t, loadTestMigrations(t))
+	require.NoError(t, migrator.Up(ctx))
+
+	// An older binary only knows the first migration.
+	err := New(db, loadTestMigrations(t)[:1]).Up(ctx)
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+}
+
+// A failing migration is rolled back whole and not recorded.
+func TestMigrator_Failure(t *testing.T) {
+	ctx := context.Background()
+	migrations := loadTestMigrations(t)
+	migrations[1].Up = `ALTER TABLE things ADD COLUMN colour TEXT; SELECT * FROM missing;`
+
+	migrator, db := newTestMigrator(t, migrations)
+	assert.Error(t, migrator.Up(ctx))
+	assert.Equal(t, []string{"id"}, columns(t, db, "things"))
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
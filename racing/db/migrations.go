@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+
+	"github.com/Kim-Hardie/entain-master/racing/db/migrate"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+)
+
+//go:embed migrations/*/*.sql
+var migrationFiles embed.FS
+
+// migrationDirs maps each dialect to the directory holding its migrations.
+// Both directories hold the same versions, written for each database.
+var migrationDirs = map[dialect.Dialect]string{
+	dialect.SQLite:   "migrations/sqlite",
+	dialect.Postgres: "migrations/postgres",
+}
+
+// NewMigrator returns a migrator holding the racing database's schema
+// migrations for the database's dialect.
+func NewMigrator(db *sql.DB) (*migrate.Migrator, error) {
+	files, err := fs.Sub(migrationFiles, migrationDirs[dialect.Of(db)])
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := migrate.Load(files)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range migrations {
+		// The status column was first rolled out by hand with AddStatus.sql,
+		// so some SQLite databases already have it.
+		if migrations[i].Name == "add_race_status" && dialect.Of(db) == dialect.SQLite {
+			migrations[i].Skip = columnExists("races", "status")
+		}
+	}
+
+	return migrate.New(db, migrations), nil
+}
+
+// columnExists returns a migrate.Migration Skip func reporting whether table
+// already has column.
+func columnExists(table, column string) func(ctx context.Context, tx *sql.Tx) (bool, error) {
+	return func(ctx context.Context, tx *sql.Tx) (bool, error) {
+		var count int
+		err := tx.QueryRowContext(ctx, `SELECT count(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&count)
+		return count > 0, err
+	}
+}
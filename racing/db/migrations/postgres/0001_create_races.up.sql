@@ -0,0 +1 @@
+CREATE TABLE IF NOT EXISTS races (id BIGINT PRIMARY KEY, meeting_id BIGINT, name TEXT, number BIGINT, visible BOOLEAN, advertised_start_time TIMESTAMPTZ);
@@ -0,0 +1 @@
+DROP TABLE race_status_transitions;
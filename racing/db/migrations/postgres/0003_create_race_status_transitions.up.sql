@@ -0,0 +1,2 @@
+-- Every status transition is recorded alongside the races table.
+CREATE TABLE IF NOT EXISTS race_status_transitions (id BIGSERIAL PRIMARY KEY, race_id BIGINT NOT NULL REFERENCES races(id), from_status TEXT NOT NULL, to_status TEXT NOT NULL, reason TEXT, transitioned_at TIMESTAMPTZ NOT NULL);
@@ -0,0 +1,2 @@
+ALTER TABLE races DROP CONSTRAINT races_meeting_id_fkey;
+DROP TABLE meetings;
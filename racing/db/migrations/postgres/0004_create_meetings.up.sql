@@ -0,0 +1,6 @@
+-- Races already reference meetings by ID, so each referenced meeting gets a
+-- placeholder row dated by its first race, with its details left to be filled in.
+CREATE TABLE IF NOT EXISTS meetings (id BIGINT PRIMARY KEY, venue TEXT NOT NULL DEFAULT '', country TEXT NOT NULL DEFAULT '', race_type TEXT NOT NULL DEFAULT 'RACE_TYPE_UNSPECIFIED', date DATE NOT NULL, track_condition TEXT NOT NULL DEFAULT '', weather TEXT NOT NULL DEFAULT '');
+INSERT INTO meetings (id, date)
+    SELECT meeting_id, min(advertised_start_time)::date FROM races WHERE meeting_id IS NOT NULL GROUP BY meeting_id;
+ALTER TABLE races ADD CONSTRAINT races_meeting_id_fkey FOREIGN KEY (meeting_id) REFERENCES meetings(id);
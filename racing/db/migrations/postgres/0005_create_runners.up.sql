@@ -0,0 +1,2 @@
+-- Each race's field, numbered uniquely within the race.
+CREATE TABLE IF NOT EXISTS runners (id BIGSERIAL PRIMARY KEY, race_id BIGINT NOT NULL REFERENCES races(id), number BIGINT NOT NULL, name TEXT NOT NULL, barrier BIGINT NOT NULL DEFAULT 0, jockey TEXT NOT NULL DEFAULT '', trainer TEXT NOT NULL DEFAULT '', weight DOUBLE PRECISION NOT NULL DEFAULT 0, scratched BOOLEAN NOT NULL DEFAULT FALSE, silks_url TEXT NOT NULL DEFAULT '', UNIQUE (race_id, number));
@@ -0,0 +1,3 @@
+-- A race's result and the placings it records, replaced whenever the result is resubmitted.
+CREATE TABLE IF NOT EXISTS results (race_id BIGINT PRIMARY KEY REFERENCES races(id), protest_pending BOOLEAN NOT NULL DEFAULT FALSE, submitted_at TIMESTAMPTZ NOT NULL);
+CREATE TABLE IF NOT EXISTS placings (race_id BIGINT NOT NULL REFERENCES results(race_id), runner_number BIGINT NOT NULL, position BIGINT NOT NULL, PRIMARY KEY (race_id, runner_number));
@@ -0,0 +1 @@
+ALTER TABLE races DROP COLUMN category;
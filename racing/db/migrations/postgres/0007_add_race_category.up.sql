@@ -0,0 +1,3 @@
+-- A race's category is its meeting's race type, copied onto the race so races can be filtered by it directly.
+ALTER TABLE races ADD COLUMN category TEXT NOT NULL DEFAULT 'RACE_TYPE_UNSPECIFIED';
+UPDATE races SET category = meetings.race_type FROM meetings WHERE meetings.id = races.meeting_id;
@@ -0,0 +1 @@
+DROP TABLE race_visibility_audit;
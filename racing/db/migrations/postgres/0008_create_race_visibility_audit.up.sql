@@ -0,0 +1,3 @@
+-- Every change to a race's visibility, with who made it and why. Entries are kept after their race is deleted.
+CREATE TABLE IF NOT EXISTS race_visibility_audit (id BIGSERIAL PRIMARY KEY, race_id BIGINT NOT NULL, visible BOOLEAN NOT NULL, actor TEXT NOT NULL, reason TEXT NOT NULL, changed_at TIMESTAMPTZ NOT NULL);
+CREATE INDEX IF NOT EXISTS race_visibility_audit_race_id ON race_visibility_audit (race_id);
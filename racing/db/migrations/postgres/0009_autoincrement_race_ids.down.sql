@@ -0,0 +1 @@
+ALTER TABLE races ALTER COLUMN id DROP IDENTITY;
@@ -0,0 +1,4 @@
+-- A deleted race's id is never handed out again, so its audit entries and watch events can't be mistaken for a later race's.
+ALTER TABLE races ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY;
+-- Races deleted before now are only remembered by the audit, so start after their ids too.
+SELECT setval(pg_get_serial_sequence('races', 'id'), GREATEST((SELECT COALESCE(MAX(id), 0) FROM races), (SELECT COALESCE(MAX(race_id), 0) FROM race_visibility_audit)) + 1, false);
@@ -0,0 +1,6 @@
+-- SQLite 3.34 can't drop columns, so rebuild the table without it.
+CREATE TABLE races_without_status (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME);
+INSERT INTO races_without_status (id, meeting_id, name, number, visible, advertised_start_time)
+    SELECT id, meeting_id, name, number, visible, advertised_start_time FROM races;
+DROP TABLE races;
+ALTER TABLE races_without_status RENAME TO races;
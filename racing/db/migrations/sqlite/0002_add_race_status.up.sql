@@ -0,0 +1,3 @@
+-- Skipped on databases that were given the column by hand before migrations
+-- existed; see migrations.go.
+ALTER TABLE races ADD COLUMN status TEXT NOT NULL DEFAULT 'OPEN';
@@ -0,0 +1,2 @@
+-- Every status transition is recorded alongside the races table.
+CREATE TABLE IF NOT EXISTS race_status_transitions (id INTEGER PRIMARY KEY AUTOINCREMENT, race_id INTEGER NOT NULL REFERENCES races(id), from_status TEXT NOT NULL, to_status TEXT NOT NULL, reason TEXT, transitioned_at DATETIME NOT NULL);
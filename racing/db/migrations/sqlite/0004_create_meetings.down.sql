@@ -0,0 +1,6 @@
+CREATE TABLE races_without_meetings (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, status TEXT NOT NULL DEFAULT 'OPEN');
+INSERT INTO races_without_meetings (id, meeting_id, name, number, visible, advertised_start_time, status)
+    SELECT id, meeting_id, name, number, visible, advertised_start_time, status FROM races;
+DROP TABLE races;
+ALTER TABLE races_without_meetings RENAME TO races;
+DROP TABLE meetings;
@@ -0,0 +1,11 @@
+-- Races already reference meetings by ID, so each referenced meeting gets a
+-- placeholder row dated by its first race, with its details left to be filled in.
+CREATE TABLE IF NOT EXISTS meetings (id INTEGER PRIMARY KEY, venue TEXT NOT NULL DEFAULT '', country TEXT NOT NULL DEFAULT '', race_type TEXT NOT NULL DEFAULT 'RACE_TYPE_UNSPECIFIED', date DATE NOT NULL, track_condition TEXT NOT NULL DEFAULT '', weather TEXT NOT NULL DEFAULT '');
+INSERT INTO meetings (id, date)
+    SELECT meeting_id, date(min(advertised_start_time)) FROM races WHERE meeting_id IS NOT NULL GROUP BY meeting_id;
+-- SQLite can't add a foreign key to an existing column, so rebuild the table with one.
+CREATE TABLE races_with_meetings (id INTEGER PRIMARY KEY, meeting_id INTEGER REFERENCES meetings(id), name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, status TEXT NOT NULL DEFAULT 'OPEN');
+INSERT INTO races_with_meetings (id, meeting_id, name, number, visible, advertised_start_time, status)
+    SELECT id, meeting_id, name, number, visible, advertised_start_time, status FROM races;
+DROP TABLE races;
+ALTER TABLE races_with_meetings RENAME TO races;
@@ -0,0 +1,2 @@
+-- Each race's field, numbered uniquely within the race.
+CREATE TABLE IF NOT EXISTS runners (id INTEGER PRIMARY KEY AUTOINCREMENT, race_id INTEGER NOT NULL REFERENCES races(id), number INTEGER NOT NULL, name TEXT NOT NULL, barrier INTEGER NOT NULL DEFAULT 0, jockey TEXT NOT NULL DEFAULT '', trainer TEXT NOT NULL DEFAULT '', weight REAL NOT NULL DEFAULT 0, scratched INTEGER NOT NULL DEFAULT 0, silks_url TEXT NOT NULL DEFAULT '', UNIQUE (race_id, number));
@@ -0,0 +1,2 @@
+DROP TABLE placings;
+DROP TABLE results;
@@ -0,0 +1,3 @@
+-- A race's result and the placings it records, replaced whenever the result is resubmitted.
+CREATE TABLE IF NOT EXISTS results (race_id INTEGER PRIMARY KEY REFERENCES races(id), protest_pending INTEGER NOT NULL DEFAULT 0, submitted_at DATETIME NOT NULL);
+CREATE TABLE IF NOT EXISTS placings (race_id INTEGER NOT NULL REFERENCES results(race_id), runner_number INTEGER NOT NULL, position INTEGER NOT NULL, PRIMARY KEY (race_id, runner_number));
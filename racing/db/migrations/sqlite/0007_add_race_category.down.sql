@@ -0,0 +1,6 @@
+-- SQLite 3.34 can't drop columns, so rebuild the table without it.
+CREATE TABLE races_without_category (id INTEGER PRIMARY KEY, meeting_id INTEGER REFERENCES meetings(id), name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, status TEXT NOT NULL DEFAULT 'OPEN');
+INSERT INTO races_without_category (id, meeting_id, name, number, visible, advertised_start_time, status)
+    SELECT id, meeting_id, name, number, visible, advertised_start_time, status FROM races;
+DROP TABLE races;
+ALTER TABLE races_without_category RENAME TO races;
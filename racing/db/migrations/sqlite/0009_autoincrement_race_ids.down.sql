@@ -0,0 +1,5 @@
+CREATE TABLE races_without_autoincrement (id INTEGER PRIMARY KEY, meeting_id INTEGER REFERENCES meetings(id), name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, status TEXT NOT NULL DEFAULT 'OPEN', category TEXT NOT NULL DEFAULT 'RACE_TYPE_UNSPECIFIED');
+INSERT INTO races_without_autoincrement (id, meeting_id, name, number, visible, advertised_start_time, status, category)
+    SELECT id, meeting_id, name, number, visible, advertised_start_time, status, category FROM races;
+DROP TABLE races;
+ALTER TABLE races_without_autoincrement RENAME TO races;
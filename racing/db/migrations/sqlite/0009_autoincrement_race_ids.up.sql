@@ -0,0 +1,11 @@
+-- A deleted race's id is never handed out again, so its audit entries and watch events can't be mistaken for a later race's.
+-- SQLite can only make a key AUTOINCREMENT when the table is created, so rebuild the table with one.
+CREATE TABLE races_autoincrement (id INTEGER PRIMARY KEY AUTOINCREMENT, meeting_id INTEGER REFERENCES meetings(id), name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, status TEXT NOT NULL DEFAULT 'OPEN', category TEXT NOT NULL DEFAULT 'RACE_TYPE_UNSPECIFIED');
+INSERT INTO races_autoincrement (id, meeting_id, name, number, visible, advertised_start_time, status, category)
+    SELECT id, meeting_id, name, number, visible, advertised_start_time, status, category FROM races;
+DROP TABLE races;
+ALTER TABLE races_autoincrement RENAME TO races;
+-- Races deleted before now are only remembered by the audit, so start after their ids too.
+DELETE FROM sqlite_sequence WHERE name = 'races';
+INSERT INTO sqlite_sequence (name, seq)
+    SELECT 'races', max((SELECT coalesce(max(id), 0) FROM races), (SELECT coalesce(max(race_id), 0) FROM race_visibility_audit));
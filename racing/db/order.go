@@ -0,0 +1,178 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+)
+
+// ErrInvalidOrderBy is returned when an order_by clause can't be parsed or
+// names a field races can't be sorted by. It is an ErrInvalidFilter.
+var ErrInvalidOrderBy = fmt.Errorf("%w: bad order_by", ErrInvalidFilter)
+
+// sortField describes a race field that can appear in order_by.
+type sortField struct {
+	// column is the races column holding the field.
+	column string
+	// timestamp marks start times, which are compared through the dialect's
+	// Timestamp as SQLite may store them with any UTC offset.
+	timestamp bool
+	// value extracts the field from a race for use in a page cursor.
+	value func(race *racing.Race) interface{}
+}
+
+// expr returns the SQL expression races are ordered and compared by.
+func (f sortField) expr(d dialect.Dialect) string {
+	if f.timestamp {
+		return d.Timestamp(f.column)
+	}
+
+	return f.column
+}
+
+// param returns the placeholder a cursor value is bound to when comparing
+// against expr.
+func (f sortField) param(d dialect.Dialect) string {
+	if f.timestamp {
+		return d.Timestamp("?")
+	}
+
+	return "?"
+}
+
+// raceSortFields whitelists the fields ListRaces can be ordered by.
+var raceSortFields = map[string]sortField{
+	"id": {
+		column: "id",
+		value:  func(race *racing.Race) interface{} { return race.Id },
+	},
+	"meeting_id": {
+		column: "meeting_id",
+		value:  func(race *racing.Race) interface{} { return race.MeetingId },
+	},
+	"name": {
+		column: "name",
+		value:  func(race *racing.Race) interface{} { return race.Name },
+	},
+	"number": {
+		column: "number",
+		value:  func(race *racing.Race) interface{} { return race.Number },
+	},
+	"visible": {
+		column: "visible",
+		value:  func(race *racing.Race) interface{} { return race.Visible },
+	},
+	"advertised_start_time": {
+		column:    "advertised_start_time",
+		timestamp: true,
+		value:     func(race *racing.Race) interface{} { return race.AdvertisedStartTime.AsTime() },
+	},
+}
+
+// orderTerm is a single field of a parsed order_by clause.
+type orderTerm struct {
+	field      sortField
+	descending bool
+}
+
+// parseOrderBy parses an AIP-132 order_by clause, e.g.
+// "meeting_id, number desc". The result always ends with id, so the ordering
+// is total and page cursors are unambiguous.
+func parseOrderBy(orderBy string) ([]orderTerm, error) {
+	var (
+		terms []orderTerm
+		seen  = make(map[string]bool)
+	)
+
+	if strings.TrimSpace(orderBy) != "" {
+		for _, part := range strings.Split(orderBy, ",") {
+			words := strings.Fields(part)
+			if len(words) == 0 || len(words) > 2 {
+				return nil, fmt.Errorf("%w: malformed term %q", ErrInvalidOrderBy, strings.TrimSpace(part))
+			}
+
+			name := words[0]
+			field, ok := raceSortFields[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidOrderBy, name)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("%w: field %q given more than once", ErrInvalidOrderBy, name)
+			}
+			seen[name] = true
+
+			term := orderTerm{field: field}
+			if len(words) == 2 {
+				switch strings.ToLower(words[1]) {
+				case "asc":
+				case "desc":
+					term.descending = true
+				default:
+					return nil, fmt.Errorf("%w: unknown direction %q for field %q", ErrInvalidOrderBy, words[1], name)
+				}
+			}
+
+			terms = append(terms, term)
+		}
+	}
+
+	if !seen["id"] {
+		terms = append(terms, orderTerm{field: raceSortFields["id"]})
+	}
+
+	return terms, nil
+}
+
+// legacyOrderBy maps the deprecated orderAscending filter onto an order_by
+// clause, defaulting to ascending start time.
+func legacyOrderBy(filter *racing.ListRacesRequestFilter) string {
+	if filter != nil && filter.OrderAscending != nil && !*filter.OrderAscending {
+		return "advertised_start_time desc"
+	}
+
+	return "advertised_start_time"
+}
+
+// orderClause renders terms as an ORDER BY clause for d.
+func orderClause(terms []orderTerm, d dialect.Dialect) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if term.descending {
+			parts = append(parts, term.field.expr(d)+" DESC")
+		} else {
+			parts = append(parts, term.field.expr(d)+" ASC")
+		}
+	}
+
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+// seekClause returns a condition matching the rows after values in the
+// ordering given by terms, i.e. (a > ?) OR (a = ? AND b > ?) OR ...
+func seekClause(terms []orderTerm, values []interface{}, d dialect.Dialect) (string, []interface{}) {
+	var (
+		disjuncts []string
+		args      []interface{}
+	)
+
+	for i, term := range terms {
+		var conjuncts []string
+		for j := 0; j < i; j++ {
+			conjuncts = append(conjuncts, terms[j].field.expr(d)+" = "+terms[j].field.param(d))
+			args = append(args, values[j])
+		}
+
+		comparison := " > "
+		if term.descending {
+			comparison = " < "
+		}
+		conjuncts = append(conjuncts, term.field.expr(d)+comparison+term.field.param(d))
+		args = append(args, values[i])
+
+		disjuncts = append(disjuncts, "("+strings.Join(conjuncts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(disjuncts, " OR ") + ")", args
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		orderBy string
+		want    string
+		wantErr bool
+	}{
+		{name: "Empty orders by id", orderBy: "", want: " ORDER BY id ASC"},
+		{name: "Single field", orderBy: "number", want: " ORDER BY number ASC, id ASC"},
+		{
+			name:    "Multiple fields with directions",
+			orderBy: "meeting_id, number desc, advertised_start_time",
+			want:    " ORDER BY meeting_id ASC, number DESC, datetime(advertised_start_time) ASC, id ASC",
+		},
+		{name: "Redundant whitespace", orderBy: "  name   DESC ,number ", want: " ORDER BY name DESC, number ASC, id ASC"},
+		{name: "Explicit id isn't repeated", orderBy: "id desc", want: " ORDER BY id DESC"},
+		{name: "Unknown field", orderBy: "status", wantErr: true},
+		{name: "Unknown direction", orderBy: "name sideways", wantErr: true},
+		{name: "Duplicate field", orderBy: "name, name desc", wantErr: true},
+		{name: "Empty term", orderBy: "name,,number", wantErr: true},
+		{name: "Too many words", orderBy: "name desc please", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms, err := parseOrderBy(tt.orderBy)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidOrderBy)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, orderClause(terms, dialect.SQLite))
+		})
+	}
+}
+
+// PostgreSQL stores start times as TIMESTAMPTZ, so they're compared directly.
+func TestOrderClause_Postgres(t *testing.T) {
+	terms, err := parseOrderBy("advertised_start_time desc")
+	assert.NoError(t, err)
+	assert.Equal(t, " ORDER BY advertised_start_time DESC, id ASC", orderClause(terms, dialect.Postgres))
+
+	clause, args := seekClause(terms, []interface{}{"t", int64(3)}, dialect.Postgres)
+	assert.Equal(t, "((advertised_start_time < ?) OR (advertised_start_time = ? AND id > ?))", clause)
+	assert.Equal(t, []interface{}{"t", "t", int64(3)}, args)
+}
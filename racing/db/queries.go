@@ -1,11 +1,65 @@
 package db
 
+import (
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+	"github.com/Kim-Hardie/entain-master/storage/fts"
+)
+
 const (
-	racesList = "list"
+	racesList             = "list"
+	racesFrom             = "from"
+	racesSetStatus        = "setStatus"
+	raceTransitionsInsert = "insertTransition"
+	meetingsList          = "listMeetings"
+	runnersList           = "listRunners"
+	raceExists            = "raceExists"
+	resultsGet            = "getResult"
+	resultsUpsert         = "upsertResult"
+	placingsList          = "listPlacings"
+	placingsDelete        = "deletePlacings"
+	placingsInsert        = "insertPlacing"
+	racesInsert           = "insert"
+	racesDelete           = "delete"
+	resultsDelete         = "deleteResult"
+	runnersDelete         = "deleteRunners"
+	raceTransitionsDelete = "deleteTransitions"
+	raceNumberTaken       = "numberTaken"
+	raceChildren          = "children"
+	meetingRaceType       = "meetingRaceType"
+	racesSetVisible       = "setVisible"
+	auditInsert           = "insertAudit"
+	auditList             = "listAudit"
+	racesSearch           = "search"
+	racesJumped           = "jumped"
 )
 
-func getRaceQueries() map[string]string {
+// getRaceQueries returns the SQL queries for races, with ? placeholders to be
+// rebound for d. An OPEN race reads as CLOSED once its advertised_start_time
+// has passed the time bound to the first placeholder, so races select from a
+// derived table exposing the effective status as a column.
+func getRaceQueries(d dialect.Dialect) map[string]string {
+	// from is what races are selected from, kept apart so the columns
+	// selected can be trimmed to a read mask.
+	from := `
+			FROM (
+				SELECT
+					id,
+					meeting_id,
+					name,
+					number,
+					visible,
+					advertised_start_time,
+					CASE
+						WHEN status = 'OPEN' AND ` + d.Timestamp("advertised_start_time") + ` <= ` + d.Timestamp("?") + ` THEN 'CLOSED'
+						ELSE status
+					END AS status,
+					category
+				FROM races
+			) AS races
+		`
+
 	return map[string]string{
+		racesFrom: from,
 		racesList: `
 			SELECT 
 				id, 
@@ -14,8 +68,93 @@ func getRaceQueries() map[string]string {
 				number, 
 				visible, 
 				advertised_start_time,
-				status
+				status,
+				category
+		` + from,
+		racesSetStatus: `UPDATE races SET status = ? WHERE id = ?`,
+		raceTransitionsInsert: `
+			INSERT INTO race_status_transitions(race_id, from_status, to_status, reason, transitioned_at)
+			VALUES (?, ?, ?, ?, ?)
+		`,
+		meetingsList: `
+			SELECT
+				id,
+				venue,
+				country,
+				race_type,
+				date,
+				track_condition,
+				weather
+			FROM meetings
+		`,
+		runnersList: `
+			SELECT
+				id,
+				race_id,
+				number,
+				name,
+				barrier,
+				jockey,
+				trainer,
+				weight,
+				scratched,
+				silks_url
+			FROM runners
+		`,
+		raceExists: `SELECT count(*) FROM races WHERE id = ?`,
+		resultsGet: `SELECT race_id, protest_pending, submitted_at FROM results WHERE race_id = ?`,
+		resultsUpsert: `
+			INSERT INTO results(race_id, protest_pending, submitted_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (race_id) DO UPDATE SET
+				protest_pending = excluded.protest_pending,
+				submitted_at = excluded.submitted_at
+		`,
+		placingsList:   `SELECT runner_number, position FROM placings WHERE race_id = ? ORDER BY position, runner_number`,
+		placingsDelete: `DELETE FROM placings WHERE race_id = ?`,
+		placingsInsert: `INSERT INTO placings(race_id, runner_number, position) VALUES (?, ?, ?)`,
+		// racesInsert leaves the id to the database, which never hands out
+		// a deleted race's id again. Races start hidden, so showing them is
+		// audited.
+		racesInsert: `
+			INSERT INTO races(meeting_id, name, number, visible, advertised_start_time, status, category)
+			VALUES (?, ?, ?, FALSE, ?, 'OPEN', ?)
+		`,
+		racesDelete:           `DELETE FROM races WHERE id = ?`,
+		resultsDelete:         `DELETE FROM results WHERE race_id = ?`,
+		runnersDelete:         `DELETE FROM runners WHERE race_id = ?`,
+		raceTransitionsDelete: `DELETE FROM race_status_transitions WHERE race_id = ?`,
+		raceNumberTaken:       `SELECT count(*) FROM races WHERE meeting_id = ? AND number = ? AND id <> ?`,
+		raceChildren: `
+			SELECT
+				(SELECT count(*) FROM runners WHERE race_id = ?) +
+				(SELECT count(*) FROM results WHERE race_id = ?)
+		`,
+		meetingRaceType: `SELECT race_type FROM meetings WHERE id = ?`,
+		racesSetVisible: `UPDATE races SET visible = ? WHERE id = ?`,
+		auditInsert: `
+			INSERT INTO race_visibility_audit(race_id, visible, actor, reason, changed_at)
+			VALUES (?, ?, ?, ?, ?)
+		`,
+		auditList: `SELECT id, race_id, visible, actor, reason, changed_at FROM race_visibility_audit`,
+		// racesJumped reads status as stored, not derived, so races closed
+		// by hand before they jumped are left out.
+		racesJumped: `
+			SELECT id, meeting_id, name, number, visible, advertised_start_time, status, category
 			FROM races
+			WHERE status = 'OPEN'
+				AND ` + d.Timestamp("advertised_start_time") + ` >= ` + d.Timestamp("?") + `
+				AND ` + d.Timestamp("advertised_start_time") + ` < ` + d.Timestamp("?") + `
+			ORDER BY id
+		`,
+		// racesSearch needs SQLite's FTS5, see search.go.
+		racesSearch: `
+			SELECT races_fts.rowid, ` + fts.Snippet("races_fts", 0) + `, bm25(races_fts)
+			FROM races_fts
+			JOIN races ON races.id = races_fts.rowid
+			WHERE races_fts MATCH ? AND races.visible
+			ORDER BY bm25(races_fts), races_fts.rowid
+			LIMIT ?
 		`,
 	}
 }
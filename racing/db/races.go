@@ -1,43 +1,175 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"github.com/golang/protobuf/ptypes"
 	_ "github.com/mattn/go-sqlite3"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/Kim-Hardie/entain-master/racing/proto/racing"
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/Kim-Hardie/entain-master/racing/db/migrate"
+	"github.com/Kim-Hardie/entain-master/storage"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
 )
 
 // RacesRepo provides repository access to races.
 type RacesRepo interface {
-	// Init will initialise our races repository.
+	// Init will migrate and initialise our races repository.
 	Init() error
 
-	// List will return a list of races.
-	List(filter *racing.ListRacesRequestFilter) ([]*racing.Race, error)
+	// List will return a page of races, along with a token for the next page
+	// if there is one.
+	List(ctx context.Context, filter *racing.ListRacesRequestFilter, opts ListOptions) ([]*racing.Race, string, error)
+
+	// GetByID will return a race by its ID, or ErrNotFound if there is none.
+	GetByID(ctx context.Context, id int64) (*racing.Race, error)
+
+	// GetByIDWithFields is GetByID reading only the given fields of the race,
+	// named as in the Race message, and its id. Empty fields read them all.
+	GetByIDWithFields(ctx context.Context, id int64, fields []string) (*racing.Race, error)
+
+	// BatchGet will return the races with the given ids in the same order,
+	// with nil for any that don't exist, using a single query.
+	BatchGet(ctx context.Context, ids []int64) ([]*racing.Race, error)
+
+	// TransitionStatus will move a race to a new status, recording the move
+	// in the race's status history, and return the race as it was read
+	// before the move and the updated race. It returns ErrNotFound if there
+	// is no such race.
+	TransitionStatus(ctx context.Context, id int64, status racing.RaceStatus, reason string) (*racing.Race, *racing.Race, error)
+
+	// Create will add a race, assigning it an id no race has had before, and
+	// return it. The race starts OPEN and hidden, as visibility only changes
+	// through SetVisibility, and takes its category from its meeting. It
+	// returns ErrInvalidRace if the meeting doesn't exist and ErrAlreadyExists
+	// if the meeting already has a race with the same number.
+	Create(ctx context.Context, race *racing.Race) (*racing.Race, error)
+
+	// Update will set the fields of the race with race's id to race's values
+	// and return the race as it was read before the update and the updated
+	// race. Fields are named as in RaceUpdateFields. It returns the same
+	// errors as Create, and ErrNotFound if there is no such race.
+	Update(ctx context.Context, race *racing.Race, fields []string) (*racing.Race, *racing.Race, error)
+
+	// Delete will delete a race and return it as it was. A race with runners
+	// or a result returns ErrRaceHasChildren unless force is set, in which
+	// case they are deleted too, along with its status history.
+	Delete(ctx context.Context, id int64, force bool) (*racing.Race, error)
+
+	// SubmitResult will record a race's placings, replacing any earlier
+	// result, and move the race to INTERIM while a protest is pending or to
+	// RESULTED otherwise. It returns the race as it was read before the
+	// result was recorded, the updated race and its result.
+	SubmitResult(ctx context.Context, id int64, placings []*racing.Placing, protestPending bool) (*racing.Race, *racing.Race, *racing.RaceResult, error)
+
+	// SetVisibility will show or hide the races with the given ids, recording
+	// each race it changes in the visibility audit with actor and reason, and
+	// return the races as they were read before the change and the updated
+	// races, both in the order asked for. Races already as asked are left out
+	// of the audit. It returns ErrNotFound, and changes nothing, if any of the
+	// races don't exist.
+	SetVisibility(ctx context.Context, ids []int64, visible bool, actor, reason string) ([]*racing.Race, []*racing.Race, error)
+
+	// ListAudit will return a page of the visibility audit, newest first,
+	// along with a token for the next page if there is one.
+	ListAudit(ctx context.Context, filter *racing.ListRaceAuditRequestFilter, pageSize int32, pageToken string) ([]*racing.RaceAuditEntry, string, error)
+
+	// Search will return up to limit visible races whose names contain every
+	// word of query, the last as a prefix, best matches first. It returns
+	// ErrSearchUnavailable unless the database is SQLite built with FTS5.
+	Search(ctx context.Context, query string, limit int32) ([]*racing.RaceSearchResult, error)
+
+	// ListJumped will return the races stored as OPEN that are advertised to
+	// start from from up to, but not including, to, visible or not. They are
+	// returned as stored, so OPEN, though each reads as CLOSED once its start
+	// time has passed.
+	ListJumped(ctx context.Context, from, to time.Time) ([]*racing.Race, error)
+
+	// GetResult will return a race's result. It returns ErrNotFound if there
+	// is no such race and ErrNoResult if the race has no result yet.
+	GetResult(ctx context.Context, id int64) (*racing.RaceResult, error)
+}
+
+// ListOptions controls the ordering of races and which page List returns.
+type ListOptions struct {
+	// PageSize is the maximum number of races to return.
+	PageSize int32
+	// PageToken is the cursor returned by a previous List call, if any.
+	PageToken string
+	// OrderBy is an AIP-132 order_by clause. When empty the deprecated
+	// orderAscending filter decides the ordering.
+	OrderBy string
+	// IncludeMeeting fills in each race's meeting.
+	IncludeMeeting bool
+	// Fields names the fields of each race to read, as in the Race message.
+	// When empty every field is read. The id, and any fields the ordering or
+	// IncludeMeeting need, are always read.
+	Fields []string
+}
 
-	// GetByID will return a race by its ID.
-	GetByID(id int64) (*racing.Race, error)
+// raceReadColumns are the columns races are read from, in the order they are
+// selected, named as in the Race message.
+var raceReadColumns = []string{"id", "meeting_id", "name", "number", "visible", "advertised_start_time", "status", "category"}
+
+// RaceUpdateFields are the fields of a race Update can set, named as in the
+// Race message. Visibility is set with SetVisibility, so it is audited.
+var RaceUpdateFields = []string{"meeting_id", "name", "number", "advertised_start_time"}
+
+// raceColumns maps each of RaceUpdateFields to the value stored for it.
+var raceColumns = map[string]func(race *racing.Race) interface{}{
+	"meeting_id": func(race *racing.Race) interface{} { return race.MeetingId },
+	"name":       func(race *racing.Race) interface{} { return race.Name },
+	"number":     func(race *racing.Race) interface{} { return race.Number },
+	"advertised_start_time": func(race *racing.Race) interface{} {
+		return race.AdvertisedStartTime.AsTime().UTC().Format(time.RFC3339)
+	},
 }
 
 type racesRepo struct {
-	db   *sql.DB
-	init sync.Once
+	db      *sql.DB
+	dialect dialect.Dialect
+	init    sync.Once
+	now     func() time.Time
+	// searchable is set by Init if the database supports Search.
+	searchable bool
 }
 
-// NewRacesRepo creates a new races repository.
+// NewRacesRepo creates a new races repository. The database may be SQLite or
+// PostgreSQL; queries are adapted to whichever driver it was opened with.
 func NewRacesRepo(db *sql.DB) RacesRepo {
-	return &racesRepo{db: db}
+	return NewRacesRepoWithClock(db, time.Now)
+}
+
+// NewRacesRepoWithClock creates a new races repository that derives race
+// status against the time returned by now, letting tests freeze time.
+func NewRacesRepoWithClock(db *sql.DB, now func() time.Time) RacesRepo {
+	return &racesRepo{db: db, dialect: dialect.Of(db), now: now}
 }
 
-// Init prepares the race repository dummy data.
+// Init brings the database schema up to date and prepares the race
+// repository dummy data.
 func (r *racesRepo) Init() error {
 	var err error
 
 	r.init.Do(func() {
+		var migrator *migrate.Migrator
+		if migrator, err = NewMigrator(r.db); err != nil {
+			return
+		}
+		if err = migrator.Up(context.Background()); err != nil {
+			return
+		}
+
+		// Before seeding, so seeded races are indexed, or stale triggers
+		// are dropped before they can fail an insert.
+		if err = r.initSearch(); err != nil {
+			return
+		}
+
 		// For test/example purposes, we seed the DB with some dummy races.
 		err = r.seed()
 	})
@@ -45,131 +177,634 @@ func (r *racesRepo) Init() error {
 	return err
 }
 
-func (r *racesRepo) List(filter *racing.ListRacesRequestFilter) ([]*racing.Race, error) {
+func (r *racesRepo) List(ctx context.Context, filter *racing.ListRacesRequestFilter, opts ListOptions) ([]*racing.Race, string, error) {
 	var (
-		err   error
-		query string
-		args  []interface{}
+		err    error
+		query  string
+		args   []interface{}
+		cursor *pageCursor
 	)
 
-	query = getRaceQueries()[racesList]
+	orderBy := opts.OrderBy
+	if orderBy == "" {
+		orderBy = legacyOrderBy(filter)
+	}
+
+	terms, err := parseOrderBy(orderBy)
+	if err != nil {
+		return nil, "", err
+	}
 
-	query, args = r.applyFilter(query, filter)
+	fingerprint := queryFingerprint(filter, orderBy)
+	if opts.PageToken != "" {
+		cursor, err = decodePageCursor(opts.PageToken, terms, fingerprint)
+		if err != nil {
+			return nil, "", err
+		}
+	}
 
-	rows, err := r.db.Query(query, args...)
+	required := []string{"id"}
+	for _, term := range terms {
+		required = append(required, term.field.column)
+	}
+	if opts.IncludeMeeting {
+		required = append(required, "meeting_id")
+	}
+	columns := readColumns(opts.Fields, required)
+
+	query = r.selectRaces(columns)
+
+	query, args = r.applyFilter(query, filter, terms, cursor)
+	args = append([]interface{}{r.now()}, args...)
+
+	// Fetch one extra row so we know whether there is another page.
+	query += " LIMIT ?"
+	args = append(args, opts.PageSize+1)
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
 	if err != nil {
-		return nil, err
+		return nil, "", storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	races, err := r.scanRaces(rows, columns)
+	if err != nil {
+		return nil, "", storage.Error(ctx, err)
+	}
+
+	var nextPageToken string
+	if len(races) > int(opts.PageSize) {
+		races = races[:opts.PageSize]
+		if nextPageToken, err = encodeRaceCursor(races[len(races)-1], terms, fingerprint); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if opts.IncludeMeeting {
+		if err := r.loadMeetings(ctx, races); err != nil {
+			return nil, "", storage.Error(ctx, err)
+		}
 	}
 
-	return r.scanRaces(rows)
+	return races, nextPageToken, nil
 }
 
-func (r *racesRepo) applyFilter(query string, filter *racing.ListRacesRequestFilter) (string, []interface{}) {
+// loadMeetings fills in the meetings of races with a single query.
+func (r *racesRepo) loadMeetings(ctx context.Context, races []*racing.Race) error {
+	if len(races) == 0 {
+		return nil
+	}
+
+	seen := make(map[int64]bool, len(races))
+	args := make([]interface{}, 0, len(races))
+	for _, race := range races {
+		if !seen[race.MeetingId] {
+			seen[race.MeetingId] = true
+			args = append(args, race.MeetingId)
+		}
+	}
+
+	query := getRaceQueries(r.dialect)[meetingsList] + " WHERE id IN (" + strings.Repeat("?,", len(args)-1) + "?)"
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	meetings, err := scanMeetings(rows)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int64]*racing.Meeting, len(meetings))
+	for _, meeting := range meetings {
+		byID[meeting.Id] = meeting
+	}
+
+	for _, race := range races {
+		race.Meeting = byID[race.MeetingId]
+	}
+
+	return nil
+}
+
+func (r *racesRepo) applyFilter(query string, filter *racing.ListRacesRequestFilter, terms []orderTerm, cursor *pageCursor) (string, []interface{}) {
 	var (
 		clauses []string
 		args    []interface{}
-		order   string
 	)
 
-	if filter == nil {
-		return query, args
-	}
-
-	if len(filter.MeetingIds) > 0 {
-		clauses = append(clauses, "meeting_id IN ("+strings.Repeat("?,", len(filter.MeetingIds)-1)+"?)")
+	if filter != nil {
+		if len(filter.MeetingIds) > 0 {
+			clauses = append(clauses, "meeting_id IN ("+strings.Repeat("?,", len(filter.MeetingIds)-1)+"?)")
 
-		for _, meetingID := range filter.MeetingIds {
-			args = append(args, meetingID)
+			for _, meetingID := range filter.MeetingIds {
+				args = append(args, meetingID)
+			}
 		}
-	}
 
-	//if no filter is set defaults to only show visible races
-	if filter.ShowOnlyVisible != nil {
-		clauses = append(clauses, "visible = ?")
-		args = append(args, *filter.ShowOnlyVisible)
-	} else {
-		clauses = append(clauses, "visible = ?")
-		args = append(args, true)
-	}
-	//if no filter is set Default to Ascending order by DateTime
-	if filter.OrderAscending != nil {
-		if *filter.OrderAscending {
-			order = " ORDER BY advertised_start_time ASC"
+		//if no filter is set defaults to only show visible races
+		if filter.ShowOnlyVisible != nil {
+			clauses = append(clauses, "visible = ?")
+			args = append(args, *filter.ShowOnlyVisible)
 		} else {
-			order = " ORDER BY advertised_start_time DESC"
+			clauses = append(clauses, "visible = ?")
+			args = append(args, true)
+		}
+
+		// status is the derived status, so this agrees with what is returned.
+		if len(filter.Statuses) > 0 {
+			clauses = append(clauses, "status IN ("+strings.Repeat("?,", len(filter.Statuses)-1)+"?)")
+
+			for _, status := range filter.Statuses {
+				args = append(args, status.String())
+			}
+		}
+
+		if len(filter.Categories) > 0 {
+			clauses = append(clauses, "category IN ("+strings.Repeat("?,", len(filter.Categories)-1)+"?)")
+
+			for _, category := range filter.Categories {
+				args = append(args, category.String())
+			}
+		}
+
+		if filter.StartTimeFrom != nil {
+			clauses = append(clauses, r.dialect.Timestamp("advertised_start_time")+" >= "+r.dialect.Timestamp("?"))
+			args = append(args, filter.StartTimeFrom.AsTime())
+		}
+
+		if filter.StartTimeTo != nil {
+			clauses = append(clauses, r.dialect.Timestamp("advertised_start_time")+" < "+r.dialect.Timestamp("?"))
+			args = append(args, filter.StartTimeTo.AsTime())
 		}
-	} else {
-		order = " ORDER BY advertised_start_time ASC"
+
+		if filter.HasResult != nil {
+			clause := "EXISTS (SELECT 1 FROM results WHERE results.race_id = races.id)"
+			if !*filter.HasResult {
+				clause = "NOT " + clause
+			}
+			clauses = append(clauses, clause)
+		}
+	}
+
+	if cursor != nil {
+		clause, cursorArgs := seekClause(terms, cursor.Values, r.dialect)
+		clauses = append(clauses, clause)
+		args = append(args, cursorArgs...)
 	}
 
 	if len(clauses) != 0 {
 		query += " WHERE " + strings.Join(clauses, " AND ")
 	}
-	query += order
+	query += orderClause(terms, r.dialect)
+
 	return query, args
 }
 
-func (r *racesRepo) scanRaces(rows *sql.Rows) ([]*racing.Race, error) {
+func (r *racesRepo) scanRaces(rows *sql.Rows, columns []string) ([]*racing.Race, error) {
 	var races []*racing.Race
 
 	for rows.Next() {
-		var race racing.Race
-		var advertisedStart time.Time
-		var status string
+		race, err := r.scanRaceColumns(rows.Scan, columns)
+		if err != nil {
+			return nil, err
+		}
 
-		if err := rows.Scan(&race.Id, &race.MeetingId, &race.Name, &race.Number, &race.Visible, &advertisedStart, &status); err != nil {
-			if err == sql.ErrNoRows {
-				return nil, nil
-			}
+		races = append(races, race)
+	}
 
-			return nil, err
+	return races, rows.Err()
+}
+func (r *racesRepo) GetByID(ctx context.Context, raceID int64) (*racing.Race, error) {
+	return r.GetByIDWithFields(ctx, raceID, nil)
+}
+
+func (r *racesRepo) GetByIDWithFields(ctx context.Context, raceID int64, fields []string) (*racing.Race, error) {
+	columns := readColumns(fields, []string{"id"})
+	query := r.dialect.Rebind(r.selectRaces(columns) + " WHERE id = ?")
+	row := r.db.QueryRowContext(ctx, query, r.now(), raceID)
+
+	race, err := r.scanRaceColumns(row.Scan, columns)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("race %d: %w", raceID, ErrNotFound)
+		}
+		return nil, storage.Error(ctx, err)
+	}
+
+	return race, nil
+}
+
+// readColumns returns the columns to select to read fields of a race, in
+// raceReadColumns order, along with the required columns. Fields that aren't
+// columns, such as runners, are left out. Empty fields read every column.
+func readColumns(fields, required []string) []string {
+	if len(fields) == 0 {
+		return raceReadColumns
+	}
+
+	// race_status and the deprecated status are both read from status.
+	if containsField(fields, "race_status") {
+		fields = append([]string{"status"}, fields...)
+	}
+
+	var columns []string
+	for _, column := range raceReadColumns {
+		if containsField(fields, column) || containsField(required, column) {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// selectRaces returns a query selecting the given columns of races, binding
+// the time their status is derived against to the first placeholder.
+func (r *racesRepo) selectRaces(columns []string) string {
+	return "SELECT " + strings.Join(columns, ", ") + getRaceQueries(r.dialect)[racesFrom]
+}
+
+func (r *racesRepo) BatchGet(ctx context.Context, ids []int64) ([]*racing.Race, error) {
+	races, err := r.getByIDs(ctx, r.db, ids, r.now())
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	return races, nil
+}
+
+// getByIDs reads the races with the given ids with one IN query, returning
+// them in the same order with nil for any that don't exist.
+func (r *racesRepo) getByIDs(ctx context.Context, q querier, ids []int64, now time.Time) ([]*racing.Race, error) {
+	races := make([]*racing.Race, len(ids))
+	if len(ids) == 0 {
+		return races, nil
+	}
+
+	args := []interface{}{now}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	query := getRaceQueries(r.dialect)[racesList] + " WHERE id IN (" + strings.Repeat("?,", len(ids)-1) + "?)"
+
+	rows, err := q.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found, err := r.scanRaces(rows, raceReadColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*racing.Race, len(found))
+	for _, race := range found {
+		byID[race.Id] = race
+	}
+
+	for i, id := range ids {
+		races[i] = byID[id]
+	}
+
+	return races, nil
+}
+
+func (r *racesRepo) ListJumped(ctx context.Context, from, to time.Time) ([]*racing.Race, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(getRaceQueries(r.dialect)[racesJumped]), from, to)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	races, err := r.scanRaces(rows, raceReadColumns)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	return races, nil
+}
+
+func (r *racesRepo) scanRace(row *sql.Row) (*racing.Race, error) {
+	return r.scanRaceColumns(row.Scan, raceReadColumns)
+}
+
+// scanRaceColumns scans a race selected with the given columns using scan,
+// leaving the fields of columns that weren't selected unset.
+func (r *racesRepo) scanRaceColumns(scan func(dest ...interface{}) error, columns []string) (*racing.Race, error) {
+	var (
+		race             racing.Race
+		advertisedStart  time.Time
+		status, category string
+	)
+
+	dest := make([]interface{}, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id":
+			dest[i] = &race.Id
+		case "meeting_id":
+			dest[i] = &race.MeetingId
+		case "name":
+			dest[i] = &race.Name
+		case "number":
+			dest[i] = &race.Number
+		case "visible":
+			dest[i] = &race.Visible
+		case "advertised_start_time":
+			dest[i] = &advertisedStart
+		case "status":
+			dest[i] = &status
+		case "category":
+			dest[i] = &category
+		default:
+			return nil, fmt.Errorf("unknown race column %q", column)
 		}
+	}
 
+	if err := scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if containsField(columns, "advertised_start_time") {
 		ts, err := ptypes.TimestampProto(advertisedStart)
 		if err != nil {
 			return nil, err
 		}
 
 		race.AdvertisedStartTime = ts
-		race.Status = status
-
-		races = append(races, &race)
+	}
+	if containsField(columns, "status") {
+		race.RaceStatus = parseRaceStatus(status)
+		// Older clients still read the status by name.
+		race.Status = race.RaceStatus.String()
+	}
+	if containsField(columns, "category") {
+		race.Category = racing.RaceType(racing.RaceType_value[category])
 	}
 
-	return races, nil
+	return &race, nil
 }
-func (r *racesRepo) GetByID(raceID int64) (*racing.Race, error) {
-	query := "SELECT id, meeting_id, name, number, visible, advertised_start_time, status FROM races WHERE id = ?"
-	row := r.db.QueryRow(query, raceID)
 
-	race, err := r.scanRace(row)
+// TransitionStatus moves a race to status if the lifecycle allows it from the
+// race's current status. It returns ErrNotFound if the race doesn't exist.
+func (r *racesRepo) TransitionStatus(ctx context.Context, raceID int64, status racing.RaceStatus, reason string) (*racing.Race, *racing.Race, error) {
+	// Serializable, so concurrent transitions can't both move the race on
+	// from the status they read on PostgreSQL.
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, nil, storage.Error(ctx, err)
+	}
+	defer tx.Rollback()
+
+	now := r.now()
+	queries := getRaceQueries(r.dialect)
+	query := r.dialect.Rebind(queries[racesList] + " WHERE id = ?")
+
+	before, err := r.scanRace(tx.QueryRowContext(ctx, query, now, raceID))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil
+			return nil, nil, fmt.Errorf("race %d: %w", raceID, ErrNotFound)
 		}
-		return nil, err
+		return nil, nil, storage.Error(ctx, err)
 	}
 
-	return race, nil
+	if err := r.transition(ctx, tx, before, status, reason, now); err != nil {
+		return nil, nil, err
+	}
+
+	// Re-read the race, as a race reopened after its start time reads as CLOSED.
+	race, err := r.scanRace(tx.QueryRowContext(ctx, query, now, raceID))
+	if err != nil {
+		return nil, nil, storage.Error(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, storage.Error(ctx, err)
+	}
+
+	return before, race, nil
 }
 
-func (r *racesRepo) scanRace(row *sql.Row) (*racing.Race, error) {
-	var race racing.Race
-	var advertisedStart time.Time
-	var status string
+// transition moves race to status within tx if the lifecycle allows it from
+// the race's current status, recording the move in its status history.
+func (r *racesRepo) transition(ctx context.Context, tx *sql.Tx, race *racing.Race, status racing.RaceStatus, reason string, now time.Time) error {
+	if !CanTransition(race.RaceStatus, status) {
+		return fmt.Errorf("%w: %s to %s", ErrIllegalTransition, race.RaceStatus, status)
+	}
+
+	queries := getRaceQueries(r.dialect)
+
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(queries[racesSetStatus]), status.String(), race.Id); err != nil {
+		return storage.Error(ctx, err)
+	}
 
-	err := row.Scan(&race.Id, &race.MeetingId, &race.Name, &race.Number, &race.Visible, &advertisedStart, &status)
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(queries[raceTransitionsInsert]), race.Id, race.RaceStatus.String(), status.String(), reason, now.Format(time.RFC3339)); err != nil {
+		return storage.Error(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *racesRepo) Create(ctx context.Context, race *racing.Race) (*racing.Race, error) {
+	// Serializable, so concurrent creates can't take the same number on
+	// PostgreSQL. SQLite transactions are always serializable.
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
-		return nil, err
+		return nil, storage.Error(ctx, err)
 	}
+	defer tx.Rollback()
+
+	queries := getRaceQueries(r.dialect)
 
-	ts, err := ptypes.TimestampProto(advertisedStart)
+	category, err := r.checkRace(ctx, tx, race, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	race.AdvertisedStartTime = ts
-	race.Status = status
+	query := queries[racesInsert]
+	args := []interface{}{race.MeetingId, race.Name, race.Number, raceColumns["advertised_start_time"](race), category}
 
-	return &race, nil
+	id, err := r.insertRace(ctx, tx, query, args...)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	created, err := r.scanRace(tx.QueryRowContext(ctx, r.dialect.Rebind(queries[racesList]+" WHERE id = ?"), r.now(), id))
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	return created, nil
+}
+
+// insertRace runs an insert of a single race and returns the id the
+// database gave it.
+func (r *racesRepo) insertRace(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	// PostgreSQL has no LastInsertId, so the id is returned by the insert.
+	if r.dialect == dialect.Postgres {
+		var id int64
+		err := tx.QueryRowContext(ctx, r.dialect.Rebind(query+" RETURNING id"), args...).Scan(&id)
+		return id, err
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func (r *racesRepo) Update(ctx context.Context, race *racing.Race, fields []string) (*racing.Race, *racing.Race, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, nil, storage.Error(ctx, err)
+	}
+	defer tx.Rollback()
+
+	now := r.now()
+	queries := getRaceQueries(r.dialect)
+	query := r.dialect.Rebind(queries[racesList] + " WHERE id = ?")
+
+	before, err := r.scanRace(tx.QueryRowContext(ctx, query, now, race.Id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, fmt.Errorf("race %d: %w", race.Id, ErrNotFound)
+		}
+		return nil, nil, storage.Error(ctx, err)
+	}
+
+	var (
+		sets []string
+		args []interface{}
+		// checked is the race's meeting and number after the update.
+		checked = &racing.Race{Id: before.Id, MeetingId: before.MeetingId, Number: before.Number}
+	)
+
+	for _, field := range fields {
+		value, ok := raceColumns[field]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s can't be updated", ErrInvalidRace, field)
+		}
+
+		sets = append(sets, field+" = ?")
+		args = append(args, value(race))
+
+		switch field {
+		case "meeting_id":
+			checked.MeetingId = race.MeetingId
+		case "number":
+			checked.Number = race.Number
+		}
+	}
+
+	// Races that already shared a number before it was checked can still
+	// be updated, as long as their meeting and number are left alone.
+	if containsField(fields, "meeting_id") || containsField(fields, "number") {
+		category, err := r.checkRace(ctx, tx, checked, checked.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sets = append(sets, "category = ?")
+		args = append(args, category)
+	}
+
+	if len(sets) > 0 {
+		args = append(args, race.Id)
+		if _, err := tx.ExecContext(ctx, r.dialect.Rebind("UPDATE races SET "+strings.Join(sets, ", ")+" WHERE id = ?"), args...); err != nil {
+			return nil, nil, storage.Error(ctx, err)
+		}
+	}
+
+	updated, err := r.scanRace(tx.QueryRowContext(ctx, query, now, race.Id))
+	if err != nil {
+		return nil, nil, storage.Error(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, storage.Error(ctx, err)
+	}
+
+	return before, updated, nil
+}
+
+// checkRace checks race's meeting exists and has no other race with race's
+// number, ignoring the race with id self, and returns the meeting's race type.
+func (r *racesRepo) checkRace(ctx context.Context, tx *sql.Tx, race *racing.Race, self int64) (string, error) {
+	queries := getRaceQueries(r.dialect)
+
+	var raceType string
+	if err := tx.QueryRowContext(ctx, r.dialect.Rebind(queries[meetingRaceType]), race.MeetingId).Scan(&raceType); err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("%w: meeting %d doesn't exist", ErrInvalidRace, race.MeetingId)
+		}
+		return "", storage.Error(ctx, err)
+	}
+
+	var taken int
+	if err := tx.QueryRowContext(ctx, r.dialect.Rebind(queries[raceNumberTaken]), race.MeetingId, race.Number, self).Scan(&taken); err != nil {
+		return "", storage.Error(ctx, err)
+	}
+	if taken > 0 {
+		return "", fmt.Errorf("meeting %d already has a race %d: %w", race.MeetingId, race.Number, ErrAlreadyExists)
+	}
+
+	return raceType, nil
+}
+
+func (r *racesRepo) Delete(ctx context.Context, raceID int64, force bool) (*racing.Race, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	defer tx.Rollback()
+
+	queries := getRaceQueries(r.dialect)
+
+	race, err := r.scanRace(tx.QueryRowContext(ctx, r.dialect.Rebind(queries[racesList]+" WHERE id = ?"), r.now(), raceID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("race %d: %w", raceID, ErrNotFound)
+		}
+		return nil, storage.Error(ctx, err)
+	}
+
+	var children int
+	if err := tx.QueryRowContext(ctx, r.dialect.Rebind(queries[raceChildren]), raceID, raceID).Scan(&children); err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	if children > 0 && !force {
+		return nil, fmt.Errorf("race %d: %w", raceID, ErrRaceHasChildren)
+	}
+
+	// Children go first, as they reference the race.
+	for _, query := range []string{placingsDelete, resultsDelete, runnersDelete, raceTransitionsDelete, racesDelete} {
+		if _, err := tx.ExecContext(ctx, r.dialect.Rebind(queries[query]), raceID); err != nil {
+			return nil, storage.Error(ctx, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	return race, nil
+}
+
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
 }
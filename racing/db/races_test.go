@@ -0,0 +1,793 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// openTestDB opens an empty database for a test. Tests run against a SQLite
+// file unless TEST_DB_DRIVER is postgres, in which case TEST_DB_DSN names a
+// PostgreSQL database and each test gets a schema of its own in it.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	if os.Getenv("TEST_DB_DRIVER") != dialect.PostgresDriver {
+		racingDB, err := sql.Open(dialect.SQLiteDriver, filepath.Join(t.TempDir(), "racing.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { racingDB.Close() })
+
+		return racingDB
+	}
+
+	dsn := os.Getenv("TEST_DB_DSN")
+	require.NotEmpty(t, dsn, "TEST_DB_DSN must be set to test against PostgreSQL")
+
+	adminDB, err := sql.Open(dialect.PostgresDriver, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { adminDB.Close() })
+
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	_, err = adminDB.Exec(`CREATE SCHEMA ` + schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { adminDB.Exec(`DROP SCHEMA ` + schema + ` CASCADE`) })
+
+	// Point every connection at the test's schema.
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		require.NoError(t, err)
+		query := u.Query()
+		query.Set("search_path", schema)
+		u.RawQuery = query.Encode()
+		dsn = u.String()
+	} else {
+		dsn += " search_path=" + schema
+	}
+
+	racingDB, err := sql.Open(dialect.PostgresDriver, dsn)
+	require.NoError(t, err)
+	// Registered after the schema is dropped, so runs before it.
+	t.Cleanup(func() { racingDB.Close() })
+
+	return racingDB
+}
+
+// skipUnlessSQLite skips tests of SQLite-specific behaviour when testing
+// against PostgreSQL.
+func skipUnlessSQLite(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv("TEST_DB_DRIVER") == dialect.PostgresDriver {
+		t.Skip("SQLite only")
+	}
+}
+
+// newTestRacesRepo returns a races repository backed by a freshly seeded database.
+func newTestRacesRepo(t *testing.T) *racesRepo {
+	t.Helper()
+
+	racingDB := openTestDB(t)
+
+	repo := NewRacesRepo(racingDB).(*racesRepo)
+	require.NoError(t, repo.Init())
+
+	return repo
+}
+
+// listAllPages pages through races pageSize at a time and returns them all.
+func listAllPages(t *testing.T, repo *racesRepo, filter *racing.ListRacesRequestFilter, opts ListOptions) []*racing.Race {
+	t.Helper()
+
+	var races []*racing.Race
+	for {
+		page, next, err := repo.List(context.Background(), filter, opts)
+		require.NoError(t, err)
+		races = append(races, page...)
+		if next == "" {
+			return races
+		}
+		opts.PageToken = next
+	}
+}
+
+// Paging through races must visit every race exactly once, in the same order as a single page.
+func TestRacesRepo_ListPagination(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	tests := []struct {
+		name    string
+		filter  *racing.ListRacesRequestFilter
+		orderBy string
+	}{
+		{name: "OrderAscending is true", filter: &racing.ListRacesRequestFilter{OrderAscending: &[]bool{true}[0]}},
+		{name: "OrderAscending is false", filter: &racing.ListRacesRequestFilter{OrderAscending: &[]bool{false}[0]}},
+		{name: "Multiple fields", filter: &racing.ListRacesRequestFilter{}, orderBy: "meeting_id, number desc, advertised_start_time"},
+		{name: "Name descending", filter: &racing.ListRacesRequestFilter{}, orderBy: "name desc"},
+		{name: "Visible then id descending", orderBy: "visible desc, id desc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			all, token, err := repo.List(context.Background(), tt.filter, ListOptions{PageSize: 1000, OrderBy: tt.orderBy})
+			require.NoError(t, err)
+			require.Empty(t, token)
+			require.NotEmpty(t, all)
+
+			paged := listAllPages(t, repo, tt.filter, ListOptions{PageSize: 7, OrderBy: tt.orderBy})
+			assert.Equal(t, all, paged)
+		})
+	}
+}
+
+// Races are sorted by each order_by field in turn, then by id.
+func TestRacesRepo_ListOrderBy(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	races, _, err := repo.List(context.Background(), nil, ListOptions{PageSize: 1000, OrderBy: "meeting_id, number desc"})
+	require.NoError(t, err)
+
+	for i := 1; i < len(races); i++ {
+		prev, cur := races[i-1], races[i]
+		switch {
+		case prev.MeetingId != cur.MeetingId:
+			assert.Less(t, prev.MeetingId, cur.MeetingId)
+		case prev.Number != cur.Number:
+			assert.Greater(t, prev.Number, cur.Number)
+		default:
+			assert.Less(t, prev.Id, cur.Id)
+		}
+	}
+}
+
+// A page token can't be reused with a different filter or ordering.
+func TestRacesRepo_ListPageTokenFilterMismatch(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	_, token, err := repo.List(context.Background(), &racing.ListRacesRequestFilter{}, ListOptions{PageSize: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	_, _, err = repo.List(context.Background(), &racing.ListRacesRequestFilter{MeetingIds: []int64{1}}, ListOptions{PageSize: 1, PageToken: token})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, _, err = repo.List(context.Background(), &racing.ListRacesRequestFilter{}, ListOptions{PageSize: 1, PageToken: token, OrderBy: "name"})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, _, err = repo.List(context.Background(), &racing.ListRacesRequestFilter{}, ListOptions{PageSize: 1, PageToken: "not-a-token"})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	// No filter lists hidden races too, so its tokens don't carry over to an
+	// empty filter, which only lists visible ones.
+	_, _, err = repo.List(context.Background(), nil, ListOptions{PageSize: 1, PageToken: token})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+}
+
+// An OPEN race reads as CLOSED once its start time passes the repository's clock.
+func TestRacesRepo_DerivedStatus(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	frozen := time.Now()
+	repo.now = func() time.Time { return frozen }
+
+	races, _, err := repo.List(context.Background(), nil, ListOptions{PageSize: 1000})
+	require.NoError(t, err)
+	require.NotEmpty(t, races)
+
+	for _, race := range races {
+		want := racing.RaceStatus_CLOSED
+		if race.AdvertisedStartTime.AsTime().After(frozen) {
+			want = racing.RaceStatus_OPEN
+		}
+		assert.Equal(t, want, race.RaceStatus, "race %d", race.Id)
+		// The deprecated status is the same status by name.
+		assert.Equal(t, want.String(), race.Status, "race %d", race.Id)
+
+		got, err := repo.GetByID(context.Background(), race.Id)
+		require.NoError(t, err)
+		assert.Equal(t, want, got.RaceStatus, "race %d", race.Id)
+	}
+
+	// Every seeded race has jumped a few days later.
+	frozen = frozen.AddDate(0, 0, 3)
+
+	races, _, err = repo.List(context.Background(), nil, ListOptions{PageSize: 1000})
+	require.NoError(t, err)
+
+	for _, race := range races {
+		assert.Equal(t, racing.RaceStatus_CLOSED, race.RaceStatus, "race %d", race.Id)
+	}
+}
+
+// Transitions follow the lifecycle, move the stored status and are recorded in the history table.
+func TestRacesRepo_TransitionStatus(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	// Freeze time before every seeded race so they all start OPEN.
+	frozen := time.Now().AddDate(0, 0, -2)
+	repo.now = func() time.Time { return frozen }
+
+	before, race, err := repo.TransitionStatus(context.Background(), 1, racing.RaceStatus_SUSPENDED, "late scratching")
+	require.NoError(t, err)
+	assert.Equal(t, racing.RaceStatus_OPEN, before.RaceStatus)
+	assert.Equal(t, racing.RaceStatus_SUSPENDED, race.RaceStatus)
+
+	_, _, err = repo.TransitionStatus(context.Background(), 1, racing.RaceStatus_RESULTED, "")
+	assert.ErrorIs(t, err, ErrIllegalTransition)
+
+	_, race, err = repo.TransitionStatus(context.Background(), 1, racing.RaceStatus_OPEN, "")
+	require.NoError(t, err)
+	assert.Equal(t, racing.RaceStatus_OPEN, race.RaceStatus)
+
+	// Once the race jumps it reads as CLOSED and can move on from there.
+	frozen = frozen.AddDate(0, 0, 5)
+
+	race, err = repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, racing.RaceStatus_CLOSED, race.RaceStatus)
+
+	before, race, err = repo.TransitionStatus(context.Background(), 1, racing.RaceStatus_INTERIM, "")
+	require.NoError(t, err)
+	assert.Equal(t, racing.RaceStatus_CLOSED, before.RaceStatus)
+	assert.Equal(t, racing.RaceStatus_INTERIM, race.RaceStatus)
+
+	_, _, err = repo.TransitionStatus(context.Background(), 1000, racing.RaceStatus_CLOSED, "")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	rows, err := repo.db.Query(`SELECT from_status, to_status, reason FROM race_status_transitions WHERE race_id = 1 ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var history [][3]string
+	for rows.Next() {
+		var transition [3]string
+		require.NoError(t, rows.Scan(&transition[0], &transition[1], &transition[2]))
+		history = append(history, transition)
+	}
+
+	assert.Equal(t, [][3]string{
+		{"OPEN", "SUSPENDED", "late scratching"},
+		{"SUSPENDED", "OPEN", ""},
+		{"CLOSED", "INTERIM", ""},
+	}, history)
+}
+
+// Of concurrent transitions from the same status only one is made, and the
+// rest fail as illegal or, if they clash with it, as unavailable.
+func TestRacesRepo_TransitionStatusConcurrent(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	frozen := time.Now().AddDate(0, 0, -2)
+	repo.now = func() time.Time { return frozen }
+
+	errs := make(chan error)
+	for i := 0; i < 5; i++ {
+		go func() {
+			_, _, err := repo.TransitionStatus(context.Background(), 1, racing.RaceStatus_SUSPENDED, "")
+			errs <- err
+		}()
+	}
+
+	var made int
+	for i := 0; i < 5; i++ {
+		err := <-errs
+		if err == nil {
+			made++
+			continue
+		}
+		assert.True(t, errors.Is(err, ErrIllegalTransition) || errors.Is(err, ErrUnavailable), err.Error())
+	}
+	assert.Equal(t, 1, made)
+
+	var transitions int
+	require.NoError(t, repo.db.QueryRow(`SELECT count(*) FROM race_status_transitions WHERE race_id = 1`).Scan(&transitions))
+	assert.Equal(t, 1, transitions)
+}
+
+// Jumps are races still stored as OPEN, visible or not, whose start times
+// fall in the window. Races closed by hand before they jumped are left out.
+func TestRacesRepo_ListJumped(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	// Well after every seeded race.
+	start := time.Now().AddDate(0, 1, 0).UTC().Truncate(time.Second)
+
+	var ids []int64
+	for i := 0; i < 4; i++ {
+		race, err := repo.Create(context.Background(), &racing.Race{
+			MeetingId:           1,
+			Name:                fmt.Sprintf("Jumper %d", i),
+			Number:              int64(51 + i),
+			AdvertisedStartTime: timestamppb.New(start.Add(time.Duration(i) * time.Minute)),
+		})
+		require.NoError(t, err)
+		ids = append(ids, race.Id)
+	}
+
+	// The third is left hidden.
+	_, _, err := repo.SetVisibility(context.Background(), []int64{ids[0], ids[1], ids[3]}, true, "jo", "card published")
+	require.NoError(t, err)
+
+	_, _, err = repo.TransitionStatus(context.Background(), ids[1], racing.RaceStatus_CLOSED, "closed early")
+	require.NoError(t, err)
+
+	races, err := repo.ListJumped(context.Background(), start, start.Add(3*time.Minute))
+	require.NoError(t, err)
+
+	var jumped []int64
+	for _, race := range races {
+		assert.Equal(t, racing.RaceStatus_OPEN, race.RaceStatus, "race %d", race.Id)
+		jumped = append(jumped, race.Id)
+	}
+	assert.Equal(t, []int64{ids[0], ids[2]}, jumped)
+}
+
+// The statuses filter matches the derived status, together with the visibility and meeting filters.
+func TestRacesRepo_ListStatuses(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	frozen := time.Now()
+	repo.now = func() time.Time { return frozen }
+
+	all, _, err := repo.List(context.Background(), &racing.ListRacesRequestFilter{MeetingIds: []int64{1, 2, 3, 4, 5}}, ListOptions{PageSize: 1000})
+	require.NoError(t, err)
+
+	_, _, err = repo.TransitionStatus(context.Background(), all[len(all)-1].Id, racing.RaceStatus_SUSPENDED, "")
+	require.NoError(t, err)
+
+	for _, statuses := range [][]racing.RaceStatus{
+		{racing.RaceStatus_OPEN},
+		{racing.RaceStatus_CLOSED},
+		{racing.RaceStatus_SUSPENDED},
+		{racing.RaceStatus_OPEN, racing.RaceStatus_SUSPENDED},
+	} {
+		filter := &racing.ListRacesRequestFilter{MeetingIds: []int64{1, 2, 3, 4, 5}, Statuses: statuses}
+
+		races, _, err := repo.List(context.Background(), filter, ListOptions{PageSize: 1000})
+		require.NoError(t, err)
+		require.NotEmpty(t, races, "statuses %v", statuses)
+
+		for _, race := range races {
+			assert.Contains(t, statuses, race.RaceStatus)
+			assert.True(t, race.Visible)
+			assert.LessOrEqual(t, race.MeetingId, int64(5))
+			if race.RaceStatus == racing.RaceStatus_OPEN {
+				assert.True(t, race.AdvertisedStartTime.AsTime().After(frozen), "race %d has jumped", race.Id)
+			}
+		}
+	}
+}
+
+// start_time_from is inclusive and start_time_to exclusive.
+func TestRacesRepo_ListStartTimeWindow(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	all, _, err := repo.List(context.Background(), nil, ListOptions{PageSize: 1000, OrderBy: "advertised_start_time"})
+	require.NoError(t, err)
+	require.True(t, len(all) > 20)
+
+	from, to := all[5].AdvertisedStartTime, all[15].AdvertisedStartTime
+	races, _, err := repo.List(context.Background(), &racing.ListRacesRequestFilter{
+		ShowOnlyVisible: &[]bool{true}[0],
+		StartTimeFrom:   from,
+		StartTimeTo:     to,
+	}, ListOptions{PageSize: 1000})
+	require.NoError(t, err)
+
+	var want []int64
+	for _, race := range all {
+		start := race.AdvertisedStartTime.AsTime()
+		if race.Visible && !start.Before(from.AsTime()) && start.Before(to.AsTime()) {
+			want = append(want, race.Id)
+		}
+	}
+
+	var got []int64
+	for _, race := range races {
+		got = append(got, race.Id)
+	}
+
+	assert.Equal(t, want, got)
+}
+
+// A missing race is ErrNotFound rather than a nil race.
+// Every race takes its category from its meeting, and can be filtered by it.
+func TestRacesRepo_ListCategories(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	all, _, err := repo.List(context.Background(), nil, ListOptions{PageSize: 1000, IncludeMeeting: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+
+	want := make(map[racing.RaceType][]int64)
+	for _, race := range all {
+		assert.Equal(t, race.Meeting.RaceType, race.Category, "race %d", race.Id)
+		if race.Visible {
+			want[race.Category] = append(want[race.Category], race.Id)
+		}
+	}
+
+	for _, categories := range [][]racing.RaceType{
+		{racing.RaceType_THOROUGHBRED},
+		{racing.RaceType_HARNESS, racing.RaceType_GREYHOUND},
+	} {
+		races, _, err := repo.List(context.Background(), &racing.ListRacesRequestFilter{Categories: categories}, ListOptions{PageSize: 1000})
+		require.NoError(t, err)
+
+		var wantIDs, gotIDs []int64
+		for _, category := range categories {
+			wantIDs = append(wantIDs, want[category]...)
+		}
+		for _, race := range races {
+			assert.Contains(t, categories, race.Category)
+			gotIDs = append(gotIDs, race.Id)
+		}
+		assert.ElementsMatch(t, wantIDs, gotIDs, "categories %v", categories)
+	}
+}
+
+func TestRacesRepo_GetByIDNotFound(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	race, err := repo.GetByID(context.Background(), 1000)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, race)
+}
+
+// A database locked by another connection is ErrUnavailable, so callers know to retry.
+func TestRacesRepo_Unavailable(t *testing.T) {
+	skipUnlessSQLite(t)
+
+	path := filepath.Join(t.TempDir(), "racing.db")
+
+	// Don't wait on the lock, so the repository fails straight away.
+	racingDB, err := sql.Open("sqlite3", path+"?_busy_timeout=0")
+	require.NoError(t, err)
+	t.Cleanup(func() { racingDB.Close() })
+
+	repo := NewRacesRepo(racingDB)
+	require.NoError(t, repo.Init())
+
+	// Hold an exclusive lock from a second connection.
+	lockDB, err := sql.Open("sqlite3", path+"?_txlock=exclusive")
+	require.NoError(t, err)
+	t.Cleanup(func() { lockDB.Close() })
+
+	lock, err := lockDB.Begin()
+	require.NoError(t, err)
+	t.Cleanup(func() { lock.Rollback() })
+
+	_, _, err = repo.List(context.Background(), nil, ListOptions{PageSize: 10})
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	_, _, err = repo.TransitionStatus(context.Background(), 1, racing.RaceStatus_SUSPENDED, "")
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
+
+// Cancelling the context aborts a slow query rather than waiting for it to finish.
+func TestRacesRepo_Cancelled(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	// Swap the races table for a view that takes seconds to read.
+	_, err := repo.db.Exec(`ALTER TABLE races RENAME TO races_data`)
+	require.NoError(t, err)
+	_, err = repo.db.Exec(`CREATE VIEW races AS SELECT * FROM races_data WHERE (
+		WITH RECURSIVE counter(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM counter WHERE n < 100000000)
+		SELECT count(*) FROM counter
+	) > 0`)
+	require.NoError(t, err)
+
+	calls := map[string]func(ctx context.Context) error{
+		"List": func(ctx context.Context) error {
+			_, _, err := repo.List(ctx, nil, ListOptions{PageSize: 10})
+			return err
+		},
+		"GetByID": func(ctx context.Context) error {
+			_, err := repo.GetByID(ctx, 1)
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := call(ctx)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Less(t, int64(time.Since(start)), int64(time.Second), "query ran on after the deadline")
+		})
+	}
+}
+
+// Init migrates databases created before migrations existed, whether or not
+// they were given the status column by hand.
+func TestRacesRepo_InitMigratesExistingDatabase(t *testing.T) {
+	skipUnlessSQLite(t)
+
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{
+			name:   "Without status",
+			schema: `CREATE TABLE races (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME)`,
+		},
+		{
+			name:   "With status added by hand",
+			schema: `CREATE TABLE races (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, status VARCHAR(10) NOT NULL DEFAULT 'OPEN')`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			racingDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "racing.db"))
+			require.NoError(t, err)
+			t.Cleanup(func() { racingDB.Close() })
+
+			_, err = racingDB.Exec(tt.schema)
+			require.NoError(t, err)
+			_, err = racingDB.Exec(`INSERT INTO races (id, meeting_id, name, number, visible, advertised_start_time) VALUES (1, 1, 'Existing', 1, 1, '2021-03-02T10:00:00Z')`)
+			require.NoError(t, err)
+
+			repo := NewRacesRepo(racingDB)
+			require.NoError(t, repo.Init())
+
+			race, err := repo.GetByID(context.Background(), 1)
+			require.NoError(t, err)
+			assert.Equal(t, "Existing", race.Name)
+			assert.Equal(t, racing.RaceStatus_CLOSED, race.RaceStatus)
+
+			// The race's meeting was created as a placeholder, then filled in by seeding.
+			meeting, err := NewMeetingsRepo(racingDB).GetByID(context.Background(), 1)
+			require.NoError(t, err)
+			assert.NotEmpty(t, meeting.Venue)
+			assert.Equal(t, "2021-03-02", meeting.Date)
+
+			migrator, err := NewMigrator(racingDB)
+			require.NoError(t, err)
+			statuses, err := migrator.Status(context.Background())
+			require.NoError(t, err)
+			for _, status := range statuses {
+				assert.True(t, status.Applied, status.Migration.String())
+			}
+		})
+	}
+}
+
+func TestRacesRepo_Create(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	meeting, err := NewMeetingsRepo(repo.db).GetByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	start := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	race, err := repo.Create(context.Background(), &racing.Race{
+		Id:                  5,
+		MeetingId:           1,
+		Name:                "Cox Plate",
+		Number:              11,
+		Visible:             true,
+		AdvertisedStartTime: timestamppb.New(start),
+		RaceStatus:          racing.RaceStatus_ABANDONED,
+	})
+	require.NoError(t, err)
+
+	// The id, visibility and status given are ignored, and the category is
+	// the meeting's.
+	assert.Equal(t, int64(101), race.Id)
+	assert.Equal(t, "Cox Plate", race.Name)
+	assert.False(t, race.Visible)
+	assert.Equal(t, start, race.AdvertisedStartTime.AsTime())
+	assert.Equal(t, racing.RaceStatus_OPEN, race.RaceStatus)
+	assert.Equal(t, meeting.RaceType, race.Category)
+
+	got, err := repo.GetByID(context.Background(), race.Id)
+	require.NoError(t, err)
+	assert.Equal(t, race, got)
+
+	_, err = repo.Create(context.Background(), &racing.Race{MeetingId: 1, Name: "Again", Number: 11, AdvertisedStartTime: timestamppb.New(start)})
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+
+	// A deleted race's id isn't handed out again.
+	_, err = repo.Delete(context.Background(), race.Id, false)
+	require.NoError(t, err)
+	again, err := repo.Create(context.Background(), &racing.Race{MeetingId: 1, Name: "Cox Plate", Number: 11, AdvertisedStartTime: timestamppb.New(start)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(102), again.Id)
+
+	_, err = repo.Create(context.Background(), &racing.Race{MeetingId: 1000, Name: "Nowhere", Number: 1, AdvertisedStartTime: timestamppb.New(start)})
+	assert.ErrorIs(t, err, ErrInvalidRace)
+}
+
+// Races deleted before ids were generated are remembered by the audit, so
+// migrating doesn't hand their ids out again.
+func TestRacesRepo_CreateAfterAuditedDelete(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	migrator, err := NewMigrator(repo.db)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Down(context.Background(), 1))
+
+	_, err = repo.db.Exec(repo.dialect.Rebind(getRaceQueries(repo.dialect)[auditInsert]), 500, true, "jo", "deleted since", time.Now().UTC())
+	require.NoError(t, err)
+
+	require.NoError(t, migrator.Up(context.Background()))
+
+	race, err := repo.Create(context.Background(), &racing.Race{MeetingId: 1, Name: "Cox Plate", Number: 11, AdvertisedStartTime: timestamppb.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(501), race.Id)
+}
+
+// Restarting doesn't seed a database that already has races, so deleted
+// races stay deleted and created races don't clash with seeded ones.
+func TestRacesRepo_InitAfterRestart(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	_, err := repo.Delete(context.Background(), 5, true)
+	require.NoError(t, err)
+	_, err = repo.Delete(context.Background(), 1, true)
+	require.NoError(t, err)
+	created, err := repo.Create(context.Background(), &racing.Race{MeetingId: 1, Name: "Cox Plate", Number: 1, AdvertisedStartTime: timestamppb.Now()})
+	require.NoError(t, err)
+
+	restarted := NewRacesRepo(repo.db)
+	require.NoError(t, restarted.Init())
+
+	_, err = restarted.GetByID(context.Background(), 5)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = restarted.GetByID(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var races, numbered int
+	require.NoError(t, repo.db.QueryRow(`SELECT count(*) FROM races`).Scan(&races))
+	assert.Equal(t, 99, races)
+	require.NoError(t, repo.db.QueryRow(`SELECT count(*) FROM races WHERE meeting_id = 1 AND number = 1`).Scan(&numbered))
+	assert.Equal(t, 1, numbered)
+
+	got, err := restarted.GetByID(context.Background(), created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, created, got)
+}
+
+func TestRacesRepo_Update(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	before, err := repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	// Fields left out of the update are left alone.
+	old, race, err := repo.Update(context.Background(), &racing.Race{Id: 1, Name: "Golden Slipper", Number: 99}, []string{"name"})
+	require.NoError(t, err)
+	assert.Equal(t, before, old)
+	assert.Equal(t, "Golden Slipper", race.Name)
+	assert.Equal(t, before.Number, race.Number)
+	assert.Equal(t, before.AdvertisedStartTime, race.AdvertisedStartTime)
+
+	// Moving meetings takes on the new meeting's category.
+	meeting, err := NewMeetingsRepo(repo.db).GetByID(context.Background(), 2)
+	require.NoError(t, err)
+	old, race, err = repo.Update(context.Background(), &racing.Race{Id: 1, MeetingId: 2, Number: 99}, []string{"meeting_id", "number"})
+	require.NoError(t, err)
+	assert.Equal(t, before.MeetingId, old.MeetingId)
+	assert.Equal(t, before.Number, old.Number)
+	assert.Equal(t, int64(2), race.MeetingId)
+	assert.Equal(t, int64(99), race.Number)
+	assert.Equal(t, meeting.RaceType, race.Category)
+
+	// Race 2 is the first race at meeting 2.
+	_, _, err = repo.Update(context.Background(), &racing.Race{Id: 1, Number: 1}, []string{"number"})
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+
+	_, _, err = repo.Update(context.Background(), &racing.Race{Id: 1, MeetingId: 1000}, []string{"meeting_id"})
+	assert.ErrorIs(t, err, ErrInvalidRace)
+
+	_, _, err = repo.Update(context.Background(), &racing.Race{Id: 1000, Name: "Missing"}, []string{"name"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRacesRepo_Delete(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	// Seeded races all have runners.
+	_, err := repo.Delete(context.Background(), 1, false)
+	assert.ErrorIs(t, err, ErrRaceHasChildren)
+
+	race, err := repo.Delete(context.Background(), 1, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), race.Id)
+
+	_, err = repo.GetByID(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var runners int
+	require.NoError(t, repo.db.QueryRow(`SELECT count(*) FROM runners WHERE race_id = 1`).Scan(&runners))
+	assert.Zero(t, runners)
+
+	// A race without children needs no force.
+	created, err := repo.Create(context.Background(), &racing.Race{MeetingId: 1, Name: "Empty", Number: 50, AdvertisedStartTime: timestamppb.Now()})
+	require.NoError(t, err)
+	_, err = repo.Delete(context.Background(), created.Id, false)
+	assert.NoError(t, err)
+
+	_, err = repo.Delete(context.Background(), 1, true)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRacesRepo_BatchGet(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	races, err := repo.BatchGet(context.Background(), []int64{7, 1000, 3, 5})
+	require.NoError(t, err)
+	require.Len(t, races, 4)
+
+	for i, id := range []int64{7, 0, 3, 5} {
+		if id == 0 {
+			assert.Nil(t, races[i])
+			continue
+		}
+
+		want, err := repo.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, want, races[i])
+	}
+
+	races, err = repo.BatchGet(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, races)
+}
+
+// Only the fields asked for are read, along with those the ordering needs.
+func TestRacesRepo_ListFields(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	all := listAllPages(t, repo, nil, ListOptions{PageSize: 1000, OrderBy: "number"})
+	trimmed := listAllPages(t, repo, nil, ListOptions{PageSize: 7, OrderBy: "number", Fields: []string{"name", "runners"}})
+	require.Len(t, trimmed, len(all))
+
+	for i, race := range trimmed {
+		assert.Equal(t, &racing.Race{Id: all[i].Id, Name: all[i].Name, Number: all[i].Number}, race)
+	}
+
+	withMeeting := listAllPages(t, repo, nil, ListOptions{PageSize: 1000, Fields: []string{"name"}, IncludeMeeting: true})
+	require.NotEmpty(t, withMeeting)
+	assert.NotNil(t, withMeeting[0].Meeting)
+	assert.Equal(t, withMeeting[0].MeetingId, withMeeting[0].Meeting.Id)
+}
+
+func TestRacesRepo_GetByIDWithFields(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	full, err := repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	race, err := repo.GetByIDWithFields(context.Background(), 1, []string{"advertised_start_time", "status"})
+	require.NoError(t, err)
+	assert.Equal(t, &racing.Race{Id: 1, AdvertisedStartTime: full.AdvertisedStartTime, RaceStatus: full.RaceStatus, Status: full.Status}, race)
+
+	// Both statuses are read from the same column, whichever is asked for.
+	race, err = repo.GetByIDWithFields(context.Background(), 1, []string{"race_status"})
+	require.NoError(t, err)
+	assert.Equal(t, &racing.Race{Id: 1, RaceStatus: full.RaceStatus, Status: full.Status}, race)
+
+	race, err = repo.GetByIDWithFields(context.Background(), 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, full, race)
+
+	_, err = repo.GetByIDWithFields(context.Background(), 1000, []string{"name"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
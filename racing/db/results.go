@@ -0,0 +1,191 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/Kim-Hardie/entain-master/storage"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	// ErrNoResult is returned when a race exists but has no result yet. It
+	// wraps ErrNotFound.
+	ErrNoResult = fmt.Errorf("%w: no result", ErrNotFound)
+
+	// ErrInvalidResult is returned when a result places a runner that isn't
+	// in the race or has been scratched.
+	ErrInvalidResult = errors.New("invalid result")
+)
+
+// querier is satisfied by both *sql.DB and *sql.Tx, so results can be read
+// inside or outside a transaction.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (r *racesRepo) SubmitResult(ctx context.Context, raceID int64, placings []*racing.Placing, protestPending bool) (*racing.Race, *racing.Race, *racing.RaceResult, error) {
+	// Serializable, as TransitionStatus is, so concurrent results can't both
+	// move the race on from the same status.
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, nil, nil, storage.Error(ctx, err)
+	}
+	defer tx.Rollback()
+
+	now := r.now()
+	queries := getRaceQueries(r.dialect)
+	query := r.dialect.Rebind(queries[racesList] + " WHERE id = ?")
+
+	before, err := r.scanRace(tx.QueryRowContext(ctx, query, now, raceID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, nil, fmt.Errorf("race %d: %w", raceID, ErrNotFound)
+		}
+		return nil, nil, nil, storage.Error(ctx, err)
+	}
+
+	status, reason := racing.RaceStatus_RESULTED, "result submitted"
+	if protestPending {
+		status, reason = racing.RaceStatus_INTERIM, "interim result submitted"
+	}
+
+	// An interim result may be amended while the protest is heard, which
+	// leaves the race INTERIM.
+	if before.RaceStatus != racing.RaceStatus_INTERIM || status != racing.RaceStatus_INTERIM {
+		if err := r.transition(ctx, tx, before, status, reason, now); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if err := r.checkPlacings(ctx, tx, raceID, placings); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(queries[placingsDelete]), raceID); err != nil {
+		return nil, nil, nil, storage.Error(ctx, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, r.dialect.Rebind(queries[resultsUpsert]), raceID, protestPending, now.UTC()); err != nil {
+		return nil, nil, nil, storage.Error(ctx, err)
+	}
+
+	for _, placing := range placings {
+		if _, err := tx.ExecContext(ctx, r.dialect.Rebind(queries[placingsInsert]), raceID, placing.RunnerNumber, placing.Position); err != nil {
+			return nil, nil, nil, storage.Error(ctx, err)
+		}
+	}
+
+	race, err := r.scanRace(tx.QueryRowContext(ctx, query, now, raceID))
+	if err != nil {
+		return nil, nil, nil, storage.Error(ctx, err)
+	}
+
+	result, err := r.loadResult(ctx, tx, raceID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, nil, storage.Error(ctx, err)
+	}
+
+	return before, race, result, nil
+}
+
+// checkPlacings returns ErrInvalidResult unless every placed runner is in the
+// race's field and hasn't been scratched.
+func (r *racesRepo) checkPlacings(ctx context.Context, tx *sql.Tx, raceID int64, placings []*racing.Placing) error {
+	rows, err := tx.QueryContext(ctx, r.dialect.Rebind(getRaceQueries(r.dialect)[runnersList]+" WHERE race_id = ?"), raceID)
+	if err != nil {
+		return storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	runners, err := scanRunners(rows)
+	if err != nil {
+		return storage.Error(ctx, err)
+	}
+
+	field := make(map[int64]*racing.Runner, len(runners))
+	for _, runner := range runners {
+		field[runner.Number] = runner
+	}
+
+	for _, placing := range placings {
+		runner, ok := field[placing.RunnerNumber]
+		if !ok {
+			return fmt.Errorf("%w: race %d has no runner %d", ErrInvalidResult, raceID, placing.RunnerNumber)
+		}
+		if runner.Scratched {
+			return fmt.Errorf("%w: runner %d in race %d is scratched", ErrInvalidResult, placing.RunnerNumber, raceID)
+		}
+	}
+
+	return nil
+}
+
+func (r *racesRepo) GetResult(ctx context.Context, raceID int64) (*racing.RaceResult, error) {
+	result, err := r.loadResult(ctx, r.db, raceID)
+	if !errors.Is(err, ErrNoResult) {
+		return result, err
+	}
+
+	// Tell a race without a result apart from a missing race.
+	var races int
+	if err := r.db.QueryRowContext(ctx, r.dialect.Rebind(getRaceQueries(r.dialect)[raceExists]), raceID).Scan(&races); err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	if races == 0 {
+		return nil, fmt.Errorf("race %d: %w", raceID, ErrNotFound)
+	}
+
+	return nil, err
+}
+
+// loadResult reads a race's result and its placings, or returns ErrNoResult
+// if the race has none.
+func (r *racesRepo) loadResult(ctx context.Context, q querier, raceID int64) (*racing.RaceResult, error) {
+	queries := getRaceQueries(r.dialect)
+
+	var (
+		result      racing.RaceResult
+		submittedAt time.Time
+	)
+
+	err := q.QueryRowContext(ctx, r.dialect.Rebind(queries[resultsGet]), raceID).Scan(&result.RaceId, &result.ProtestPending, &submittedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("race %d: %w", raceID, ErrNoResult)
+		}
+		return nil, storage.Error(ctx, err)
+	}
+	result.SubmittedAt = timestamppb.New(submittedAt)
+
+	rows, err := q.QueryContext(ctx, r.dialect.Rebind(queries[placingsList]), raceID)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var placing racing.Placing
+
+		if err := rows.Scan(&placing.RunnerNumber, &placing.Position); err != nil {
+			return nil, storage.Error(ctx, err)
+		}
+
+		result.Placings = append(result.Placings, &placing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unscratch reinstates every runner in a race, as seeding scratches some at random.
+func unscratch(t *testing.T, repo *racesRepo, raceID int64) {
+	t.Helper()
+
+	_, err := repo.db.Exec(repo.dialect.Rebind(`UPDATE runners SET scratched = ? WHERE race_id = ?`), false, raceID)
+	require.NoError(t, err)
+}
+
+// An interim result can be amended until it is made final, which results the race.
+func TestRacesRepo_SubmitResult(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	// Freeze time after every seeded race has jumped, so they all read CLOSED.
+	frozen := time.Now().AddDate(0, 0, 3)
+	repo.now = func() time.Time { return frozen }
+	unscratch(t, repo, 1)
+
+	_, err := repo.GetResult(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNoResult)
+
+	interim := []*racing.Placing{{RunnerNumber: 3, Position: 1}, {RunnerNumber: 5, Position: 2}}
+	before, race, result, err := repo.SubmitResult(context.Background(), 1, interim, true)
+	require.NoError(t, err)
+	assert.Equal(t, racing.RaceStatus_CLOSED, before.RaceStatus)
+	assert.Equal(t, racing.RaceStatus_INTERIM, race.RaceStatus)
+	assert.True(t, result.ProtestPending)
+	assert.Equal(t, interim, result.Placings)
+
+	// The protest is upheld, and the first two dead heat.
+	amended := []*racing.Placing{{RunnerNumber: 5, Position: 1}, {RunnerNumber: 3, Position: 1}, {RunnerNumber: 7, Position: 3}}
+	_, race, _, err = repo.SubmitResult(context.Background(), 1, amended, true)
+	require.NoError(t, err)
+	assert.Equal(t, racing.RaceStatus_INTERIM, race.RaceStatus)
+
+	before, race, result, err = repo.SubmitResult(context.Background(), 1, amended, false)
+	require.NoError(t, err)
+	assert.Equal(t, racing.RaceStatus_INTERIM, before.RaceStatus)
+	assert.Equal(t, racing.RaceStatus_RESULTED, race.RaceStatus)
+	assert.False(t, result.ProtestPending)
+	assert.Equal(t, []*racing.Placing{{RunnerNumber: 3, Position: 1}, {RunnerNumber: 5, Position: 1}, {RunnerNumber: 7, Position: 3}}, result.Placings)
+	assert.WithinDuration(t, frozen, result.SubmittedAt.AsTime(), time.Second)
+
+	got, err := repo.GetResult(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, result.Placings, got.Placings)
+
+	// RESULTED is final.
+	_, _, _, err = repo.SubmitResult(context.Background(), 1, amended, false)
+	assert.ErrorIs(t, err, ErrIllegalTransition)
+}
+
+func TestRacesRepo_SubmitResultErrors(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	frozen := time.Now().AddDate(0, 0, -2)
+	repo.now = func() time.Time { return frozen }
+
+	winner := []*racing.Placing{{RunnerNumber: 1, Position: 1}}
+
+	// Races that haven't jumped can't be resulted.
+	_, _, _, err := repo.SubmitResult(context.Background(), 1, winner, false)
+	assert.ErrorIs(t, err, ErrIllegalTransition)
+
+	frozen = frozen.AddDate(0, 0, 5)
+
+	_, err = repo.db.Exec(repo.dialect.Rebind(`UPDATE runners SET scratched = ? WHERE race_id = ? AND number = ?`), true, 1, 1)
+	require.NoError(t, err)
+
+	_, _, _, err = repo.SubmitResult(context.Background(), 1, winner, false)
+	assert.ErrorIs(t, err, ErrInvalidResult)
+
+	_, _, _, err = repo.SubmitResult(context.Background(), 1, []*racing.Placing{{RunnerNumber: 99, Position: 1}}, false)
+	assert.ErrorIs(t, err, ErrInvalidResult)
+
+	// A rejected result leaves the race as it was.
+	race, err := repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, racing.RaceStatus_CLOSED, race.RaceStatus)
+
+	_, _, _, err = repo.SubmitResult(context.Background(), 1000, winner, false)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = repo.GetResult(context.Background(), 1000)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrNoResult)
+}
+
+func TestRacesRepo_ListHasResult(t *testing.T) {
+	repo := newTestRacesRepo(t)
+
+	frozen := time.Now().AddDate(0, 0, 3)
+	repo.now = func() time.Time { return frozen }
+
+	// Any filter hides invisible races unless asked otherwise.
+	all := listAllPages(t, repo, &racing.ListRacesRequestFilter{}, ListOptions{PageSize: 1000})
+	require.NotEmpty(t, all)
+	unscratch(t, repo, all[0].Id)
+
+	_, _, _, err := repo.SubmitResult(context.Background(), all[0].Id, []*racing.Placing{{RunnerNumber: 1, Position: 1}}, false)
+	require.NoError(t, err)
+
+	hasResult := true
+	resulted := listAllPages(t, repo, &racing.ListRacesRequestFilter{HasResult: &hasResult}, ListOptions{PageSize: 1000})
+	require.Len(t, resulted, 1)
+	assert.Equal(t, all[0].Id, resulted[0].Id)
+
+	hasResult = false
+	unresulted := listAllPages(t, repo, &racing.ListRacesRequestFilter{HasResult: &hasResult}, ListOptions{PageSize: 1000})
+	assert.Len(t, unresulted, len(all)-1)
+	assert.NotContains(t, unresulted, resulted[0])
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/Kim-Hardie/entain-master/storage"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+)
+
+// RunnersRepo provides repository access to the runners in each race. The
+// runners schema and dummy data are set up by RacesRepo.Init.
+type RunnersRepo interface {
+	// List will return the runners in a race ordered by number, including
+	// scratched runners, or ErrNotFound if there is no such race.
+	List(ctx context.Context, raceID int64) ([]*racing.Runner, error)
+}
+
+type runnersRepo struct {
+	db      *sql.DB
+	dialect dialect.Dialect
+}
+
+// NewRunnersRepo creates a new runners repository.
+func NewRunnersRepo(db *sql.DB) RunnersRepo {
+	return &runnersRepo{db: db, dialect: dialect.Of(db)}
+}
+
+func (r *runnersRepo) List(ctx context.Context, raceID int64) ([]*racing.Runner, error) {
+	queries := getRaceQueries(r.dialect)
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(queries[runnersList]+" WHERE race_id = ? ORDER BY number"), raceID)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	runners, err := scanRunners(rows)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	// A race with no runners yet has an empty field, but a missing race is
+	// an error.
+	if len(runners) == 0 {
+		var races int
+		if err := r.db.QueryRowContext(ctx, r.dialect.Rebind(queries[raceExists]), raceID).Scan(&races); err != nil {
+			return nil, storage.Error(ctx, err)
+		}
+		if races == 0 {
+			return nil, fmt.Errorf("race %d: %w", raceID, ErrNotFound)
+		}
+	}
+
+	return runners, nil
+}
+
+// scanRunners scans the database rows and converts them to runners.
+func scanRunners(rows *sql.Rows) ([]*racing.Runner, error) {
+	var runners []*racing.Runner
+
+	for rows.Next() {
+		var runner racing.Runner
+
+		if err := rows.Scan(&runner.Id, &runner.RaceId, &runner.Number, &runner.Name, &runner.Barrier, &runner.Jockey,
+			&runner.Trainer, &runner.Weight, &runner.Scratched, &runner.SilksUrl); err != nil {
+			return nil, err
+		}
+
+		runners = append(runners, &runner)
+	}
+
+	return runners, rows.Err()
+}
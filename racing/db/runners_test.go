@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Every seeded race has a field numbered from 1, suited to its race type.
+func TestRunnersRepo_List(t *testing.T) {
+	races := newTestRacesRepo(t)
+	repo := NewRunnersRepo(races.db)
+
+	raceList, _, err := races.List(context.Background(), nil, ListOptions{PageSize: 1000, IncludeMeeting: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, raceList)
+
+	for _, race := range raceList {
+		runners, err := repo.List(context.Background(), race.Id)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(runners), 8, "race %d", race.Id)
+
+		barriers := make(map[int64]bool)
+		for i, runner := range runners {
+			assert.Equal(t, race.Id, runner.RaceId)
+			assert.Equal(t, int64(i+1), runner.Number)
+			assert.NotEmpty(t, runner.Name)
+			assert.NotEmpty(t, runner.Trainer)
+			barriers[runner.Barrier] = true
+
+			switch race.Meeting.RaceType {
+			case racing.RaceType_THOROUGHBRED:
+				assert.NotEmpty(t, runner.Jockey)
+				assert.Greater(t, runner.Weight, 0.0)
+			case racing.RaceType_GREYHOUND:
+				assert.Empty(t, runner.Jockey)
+				assert.Zero(t, runner.Weight)
+			}
+		}
+		assert.Len(t, barriers, len(runners), "race %d barriers", race.Id)
+	}
+}
+
+// A race without runners has an empty field, while a missing race is ErrNotFound.
+func TestRunnersRepo_ListEmptyAndMissing(t *testing.T) {
+	races := newTestRacesRepo(t)
+	repo := NewRunnersRepo(races.db)
+
+	_, err := races.db.Exec(races.dialect.Rebind(`DELETE FROM runners WHERE race_id = ?`), 1)
+	require.NoError(t, err)
+
+	runners, err := repo.List(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Empty(t, runners)
+
+	_, err = repo.List(context.Background(), 1000)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// Restarting doesn't give runners to races, seeded or created, that have none.
+func TestRunnersRepo_ListAfterRestart(t *testing.T) {
+	races := newTestRacesRepo(t)
+	repo := NewRunnersRepo(races.db)
+
+	_, err := races.db.Exec(races.dialect.Rebind(`DELETE FROM runners WHERE race_id = ?`), 1)
+	require.NoError(t, err)
+	created, err := races.Create(context.Background(), &racing.Race{MeetingId: 1, Name: "Cox Plate", Number: 11, AdvertisedStartTime: timestamppb.Now()})
+	require.NoError(t, err)
+
+	require.NoError(t, NewRacesRepo(races.db).Init())
+
+	for _, id := range []int64{1, created.Id} {
+		runners, err := repo.List(context.Background(), id)
+		require.NoError(t, err)
+		assert.Empty(t, runners, "race %d", id)
+	}
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/Kim-Hardie/entain-master/storage"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+	"github.com/Kim-Hardie/entain-master/storage/fts"
+)
+
+// ErrInvalidQuery is returned when a search query has no words to search
+// for. It is an ErrInvalidFilter.
+var ErrInvalidQuery = fmt.Errorf("%w: bad search query", ErrInvalidFilter)
+
+// searchSchema creates races_fts, an FTS5 index over race names, and the
+// triggers keeping it in sync with races. The index is an external content
+// table, so it stores no copy of the names.
+var searchSchema = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS races_fts USING fts5(name, content='races', content_rowid='id')`,
+	`CREATE TRIGGER IF NOT EXISTS races_fts_insert AFTER INSERT ON races BEGIN
+		INSERT INTO races_fts(rowid, name) VALUES (new.id, new.name);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS races_fts_delete AFTER DELETE ON races BEGIN
+		INSERT INTO races_fts(races_fts, rowid, name) VALUES ('delete', old.id, old.name);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS races_fts_update AFTER UPDATE OF name ON races BEGIN
+		INSERT INTO races_fts(races_fts, rowid, name) VALUES ('delete', old.id, old.name);
+		INSERT INTO races_fts(rowid, name) VALUES (new.id, new.name);
+	END`,
+	// Catch up on changes made while the triggers didn't exist, e.g. by a
+	// build without FTS5 or a migration rebuilding races.
+	`INSERT INTO races_fts(races_fts) VALUES ('rebuild')`,
+}
+
+// searchTriggers are dropped when FTS5 isn't available, as they would fail
+// every write to races.
+var searchTriggers = []string{"races_fts_insert", "races_fts_delete", "races_fts_update"}
+
+// initSearch sets up the search index if the database is SQLite built with
+// FTS5, i.e. the service was built with -tags sqlite_fts5.
+func (r *racesRepo) initSearch() error {
+	if r.dialect != dialect.SQLite {
+		return nil
+	}
+
+	searchable, err := fts.Init(r.db, searchSchema, searchTriggers)
+	if err != nil {
+		return err
+	}
+
+	r.searchable = searchable
+
+	return nil
+}
+
+func (r *racesRepo) Search(ctx context.Context, query string, limit int32) ([]*racing.RaceSearchResult, error) {
+	if !r.searchable {
+		return nil, ErrSearchUnavailable
+	}
+
+	match := fts.Query(query)
+	if match == "" {
+		return nil, fmt.Errorf("%w: %q has no words to search for", ErrInvalidQuery, query)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(getRaceQueries(r.dialect)[racesSearch]), match, limit)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	var (
+		ids     []int64
+		results []*racing.RaceSearchResult
+	)
+	for rows.Next() {
+		var (
+			id     int64
+			result racing.RaceSearchResult
+			rank   float64
+		)
+
+		if err := rows.Scan(&id, &result.Snippet, &rank); err != nil {
+			return nil, storage.Error(ctx, err)
+		}
+
+		// bm25 ranks better matches lower; scores are higher for them.
+		result.Score = -rank
+
+		ids = append(ids, id)
+		results = append(results, &result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	races, err := r.getByIDs(ctx, r.db, ids, r.now())
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	// A race deleted since it was matched is left out.
+	found := results[:0]
+	for i, race := range races {
+		if race != nil {
+			results[i].Race = race
+			found = append(found, results[i])
+		}
+	}
+
+	return found, nil
+}
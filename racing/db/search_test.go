@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRacesRepo_Search(t *testing.T) {
+	repo := newTestRacesRepo(t)
+	if !repo.searchable {
+		t.Skip("search needs -tags sqlite_fts5")
+	}
+
+	for id, race := range map[int64]struct {
+		name    string
+		visible bool
+	}{
+		1: {"Cox Plate", true},
+		2: {"Cox Plate Trial Stakes", true},
+		3: {"Cox Plate Hurdle", false},
+		4: {"Coxswain Handicap", true},
+	} {
+		_, err := repo.db.Exec(repo.dialect.Rebind(`UPDATE races SET name = ?, visible = ? WHERE id = ?`), race.name, race.visible, id)
+		require.NoError(t, err)
+	}
+
+	results, err := repo.Search(context.Background(), "cox pl", 10)
+	require.NoError(t, err)
+
+	// Hidden races aren't found, and shorter names rank higher.
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(1), results[0].Race.Id)
+	assert.Equal(t, "<mark>Cox</mark> <mark>Plate</mark>", results[0].Snippet)
+	assert.Equal(t, int64(2), results[1].Race.Id)
+	assert.Equal(t, "<mark>Cox</mark> <mark>Plate</mark> Trial Stakes", results[1].Snippet)
+	assert.Greater(t, results[0].Score, results[1].Score)
+
+	// The last word matches as a prefix.
+	results, err = repo.Search(context.Background(), "coxs", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, int64(4), results[0].Race.Id)
+
+	results, err = repo.Search(context.Background(), "cox", 1)
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	// Renamed and deleted races drop out of the index.
+	_, err = repo.db.Exec(repo.dialect.Rebind(`UPDATE races SET name = ? WHERE id = ?`), "Golden Slipper", 2)
+	require.NoError(t, err)
+	_, err = repo.Delete(context.Background(), 1, true)
+	require.NoError(t, err)
+
+	results, err = repo.Search(context.Background(), "cox plate", 10)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	results, err = repo.Search(context.Background(), "golden", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, int64(2), results[0].Race.Id)
+
+	_, err = repo.Search(context.Background(), `"*`, 10)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+}
+
+func TestRacesRepo_SearchUnavailable(t *testing.T) {
+	repo := newTestRacesRepo(t)
+	if repo.searchable {
+		t.Skip("search is available")
+	}
+
+	_, err := repo.Search(context.Background(), "cox", 10)
+	assert.ErrorIs(t, err, ErrSearchUnavailable)
+
+	// Writes still work without the index.
+	_, err = repo.db.Exec(repo.dialect.Rebind(`UPDATE races SET name = ? WHERE id = ?`), "Cox Plate", 1)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+)
+
+// ErrIllegalTransition is returned when a race can't move from its current
+// status to the one requested.
+var ErrIllegalTransition = errors.New("illegal race status transition")
+
+// raceTransitions lists the statuses a race may move to from each status.
+// RESULTED and ABANDONED are final, so they have no entry.
+var raceTransitions = map[racing.RaceStatus][]racing.RaceStatus{
+	racing.RaceStatus_OPEN:      {racing.RaceStatus_SUSPENDED, racing.RaceStatus_CLOSED, racing.RaceStatus_POSTPONED, racing.RaceStatus_ABANDONED},
+	racing.RaceStatus_SUSPENDED: {racing.RaceStatus_OPEN, racing.RaceStatus_CLOSED, racing.RaceStatus_POSTPONED, racing.RaceStatus_ABANDONED},
+	racing.RaceStatus_POSTPONED: {racing.RaceStatus_OPEN, racing.RaceStatus_ABANDONED},
+	racing.RaceStatus_CLOSED:    {racing.RaceStatus_INTERIM, racing.RaceStatus_RESULTED, racing.RaceStatus_ABANDONED},
+	racing.RaceStatus_INTERIM:   {racing.RaceStatus_RESULTED, racing.RaceStatus_ABANDONED},
+}
+
+// CanTransition reports whether a race may move from one status to another.
+func CanTransition(from, to racing.RaceStatus) bool {
+	for _, allowed := range raceTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRaceStatus maps a stored status onto the enum, falling back to
+// RACE_STATUS_UNSPECIFIED for anything unrecognised.
+func parseRaceStatus(status string) racing.RaceStatus {
+	return racing.RaceStatus(racing.RaceStatus_value[status])
+}
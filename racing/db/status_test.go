@@ -0,0 +1,34 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to racing.RaceStatus
+		want     bool
+	}{
+		{racing.RaceStatus_OPEN, racing.RaceStatus_SUSPENDED, true},
+		{racing.RaceStatus_SUSPENDED, racing.RaceStatus_OPEN, true},
+		{racing.RaceStatus_OPEN, racing.RaceStatus_CLOSED, true},
+		{racing.RaceStatus_CLOSED, racing.RaceStatus_INTERIM, true},
+		{racing.RaceStatus_INTERIM, racing.RaceStatus_RESULTED, true},
+		{racing.RaceStatus_POSTPONED, racing.RaceStatus_OPEN, true},
+		{racing.RaceStatus_OPEN, racing.RaceStatus_OPEN, false},
+		{racing.RaceStatus_OPEN, racing.RaceStatus_RESULTED, false},
+		{racing.RaceStatus_CLOSED, racing.RaceStatus_OPEN, false},
+		{racing.RaceStatus_RESULTED, racing.RaceStatus_INTERIM, false},
+		{racing.RaceStatus_ABANDONED, racing.RaceStatus_OPEN, false},
+		{racing.RaceStatus_OPEN, racing.RaceStatus_RACE_STATUS_UNSPECIFIED, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"->"+tt.to.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, CanTransition(tt.from, tt.to))
+		})
+	}
+}
@@ -1,24 +1,37 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"flag"
 	"log"
 	"net"
+	"time"
 
+	"github.com/Kim-Hardie/entain-master/proto/racing"
 	"github.com/Kim-Hardie/entain-master/racing/db"
-	"github.com/Kim-Hardie/entain-master/racing/proto/racing"
 	"github.com/Kim-Hardie/entain-master/racing/service"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
 )
 
 var (
 	grpcEndpoint = flag.String("grpc-endpoint", "localhost:9000", "gRPC server endpoint")
+	dbDriver     = flag.String("db-driver", dialect.SQLiteDriver, "database driver, sqlite3 or postgres")
+	dbDSN        = flag.String("db-dsn", "./db/racing.db", "database data source name, a file path for sqlite3 or a connection string for postgres")
 )
 
 func main() {
 	flag.Parse()
 
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(flag.Args()[1:]); err != nil {
+			log.Fatalf("failed running migrations: %s\n", err)
+		}
+		return
+	}
+
 	if err := run(); err != nil {
 		log.Fatalf("failed running grpc server: %s\n", err)
 	}
@@ -30,11 +43,12 @@ func run() error {
 		return err
 	}
 
-	racingDB, err := sql.Open("sqlite3", "./db/racing.db")
+	racingDB, err := openDB()
 	if err != nil {
 		return err
 	}
 
+	// Init applies any pending schema migrations before seeding.
 	racesRepo := db.NewRacesRepo(racingDB)
 	if err := racesRepo.Init(); err != nil {
 		return err
@@ -42,9 +56,14 @@ func run() error {
 
 	grpcServer := grpc.NewServer()
 
+	racingService := service.NewRacingService(racesRepo, db.NewMeetingsRepo(racingDB), db.NewRunnersRepo(racingDB))
+
+	// Tell watchers about races closing as they jump.
+	go racingService.ReportJumps(context.Background(), time.Second)
+
 	racing.RegisterRacingServer(
 		grpcServer,
-		service.NewRacingService(racesRepo), // pass RacingService directly
+		racingService, // pass RacingService directly
 	)
 
 	log.Printf("gRPC server listening on: %s\n", *grpcEndpoint)
@@ -55,3 +74,12 @@ func run() error {
 
 	return nil
 }
+
+// openDB opens the racing database named by the db-driver and db-dsn flags.
+func openDB() (*sql.DB, error) {
+	if _, err := dialect.ForDriver(*dbDriver); err != nil {
+		return nil, err
+	}
+
+	return sql.Open(*dbDriver, *dbDSN)
+}
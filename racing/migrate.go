@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/racing/db"
+)
+
+const migrateUsage = `usage: racing [flags] migrate [-dry-run] <command>
+
+Commands:
+  up        apply every pending migration
+  down [n]  revert the n most recently applied migrations (default 1)
+  status    list migrations and whether each has been applied
+`
+
+// runMigrate implements the migrate subcommand. The server applies pending
+// migrations itself on startup; this is for inspecting the schema, rolling
+// back and previewing changes with -dry-run.
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "print the SQL that would run without running it")
+	flags.Usage = func() { fmt.Fprint(flags.Output(), migrateUsage) }
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	racingDB, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer racingDB.Close()
+
+	migrator, err := db.NewMigrator(racingDB)
+	if err != nil {
+		return err
+	}
+	migrator.DryRun = *dryRun
+
+	ctx := context.Background()
+
+	switch flags.Arg(0) {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if flags.NArg() > 1 {
+			if steps, err = strconv.Atoi(flags.Arg(1)); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations to revert %q", flags.Arg(1))
+			}
+		}
+		return migrator.Down(ctx, steps)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MIGRATION\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\n", status.Migration, appliedAt)
+		}
+		return w.Flush()
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown migrate command %q", flags.Arg(0))
+	}
+}
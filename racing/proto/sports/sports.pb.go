@@ -1,554 +0,0 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.26.0
-// 	protoc        v3.12.4
-// source: sports.proto
-
-package sports
-
-import (
-	timestamp "github.com/golang/protobuf/ptypes/timestamp"
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	reflect "reflect"
-	sync "sync"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-// Request message for ListMatches method.
-type ListMatchesRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Filter *MatchFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
-}
-
-func (x *ListMatchesRequest) Reset() {
-	*x = ListMatchesRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_sports_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *ListMatchesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListMatchesRequest) ProtoMessage() {}
-
-func (x *ListMatchesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sports_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListMatchesRequest.ProtoReflect.Descriptor instead.
-func (*ListMatchesRequest) Descriptor() ([]byte, []int) {
-	return file_sports_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *ListMatchesRequest) GetFilter() *MatchFilter {
-	if x != nil {
-		return x.Filter
-	}
-	return nil
-}
-
-// MatchFilter specifies the filtering criteria for list matches request.
-type MatchFilter struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Stadium string `protobuf:"bytes,1,opt,name=stadium,proto3" json:"stadium,omitempty"` // Filter matches by stadium.
-	Sport   string `protobuf:"bytes,2,opt,name=sport,proto3" json:"sport,omitempty"`     // Filter matches by sport.
-}
-
-func (x *MatchFilter) Reset() {
-	*x = MatchFilter{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_sports_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *MatchFilter) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*MatchFilter) ProtoMessage() {}
-
-func (x *MatchFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_sports_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use MatchFilter.ProtoReflect.Descriptor instead.
-func (*MatchFilter) Descriptor() ([]byte, []int) {
-	return file_sports_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *MatchFilter) GetStadium() string {
-	if x != nil {
-		return x.Stadium
-	}
-	return ""
-}
-
-func (x *MatchFilter) GetSport() string {
-	if x != nil {
-		return x.Sport
-	}
-	return ""
-}
-
-// Response message for ListMatches method.
-type ListMatchesResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Matches []*Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"` // Collection of matches.
-}
-
-func (x *ListMatchesResponse) Reset() {
-	*x = ListMatchesResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_sports_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *ListMatchesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListMatchesResponse) ProtoMessage() {}
-
-func (x *ListMatchesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sports_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListMatchesResponse.ProtoReflect.Descriptor instead.
-func (*ListMatchesResponse) Descriptor() ([]byte, []int) {
-	return file_sports_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *ListMatchesResponse) GetMatches() []*Match {
-	if x != nil {
-		return x.Matches
-	}
-	return nil
-}
-
-// Request message for GetMatchByID method.
-type GetMatchByIDRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	MatchId int64 `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"` // ID of the match to retrieve.
-}
-
-func (x *GetMatchByIDRequest) Reset() {
-	*x = GetMatchByIDRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_sports_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *GetMatchByIDRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetMatchByIDRequest) ProtoMessage() {}
-
-func (x *GetMatchByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sports_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetMatchByIDRequest.ProtoReflect.Descriptor instead.
-func (*GetMatchByIDRequest) Descriptor() ([]byte, []int) {
-	return file_sports_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *GetMatchByIDRequest) GetMatchId() int64 {
-	if x != nil {
-		return x.MatchId
-	}
-	return 0
-}
-
-// Response message for GetMatchByID method.
-type GetMatchByIDResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Match *Match `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"` // Single match.
-}
-
-func (x *GetMatchByIDResponse) Reset() {
-	*x = GetMatchByIDResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_sports_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *GetMatchByIDResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetMatchByIDResponse) ProtoMessage() {}
-
-func (x *GetMatchByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sports_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetMatchByIDResponse.ProtoReflect.Descriptor instead.
-func (*GetMatchByIDResponse) Descriptor() ([]byte, []int) {
-	return file_sports_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *GetMatchByIDResponse) GetMatch() *Match {
-	if x != nil {
-		return x.Match
-	}
-	return nil
-}
-
-// A match resource.
-type Match struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id      int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`          // ID of the match.
-	Name    string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`       // Name of the match.
-	Stadium string               `protobuf:"bytes,3,opt,name=stadium,proto3" json:"stadium,omitempty"` // Stadium where the match takes place.
-	Sport   string               `protobuf:"bytes,4,opt,name=sport,proto3" json:"sport,omitempty"`     // Sport of the match.
-	Team1   string               `protobuf:"bytes,5,opt,name=team1,proto3" json:"team1,omitempty"`     // Name of team 1.
-	Team2   string               `protobuf:"bytes,6,opt,name=team2,proto3" json:"team2,omitempty"`     // Name of team 2.
-	Time    *timestamp.Timestamp `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`       // Time of the match.
-}
-
-func (x *Match) Reset() {
-	*x = Match{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_sports_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *Match) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Match) ProtoMessage() {}
-
-func (x *Match) ProtoReflect() protoreflect.Message {
-	mi := &file_sports_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Match.ProtoReflect.Descriptor instead.
-func (*Match) Descriptor() ([]byte, []int) {
-	return file_sports_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *Match) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *Match) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Match) GetStadium() string {
-	if x != nil {
-		return x.Stadium
-	}
-	return ""
-}
-
-func (x *Match) GetSport() string {
-	if x != nil {
-		return x.Sport
-	}
-	return ""
-}
-
-func (x *Match) GetTeam1() string {
-	if x != nil {
-		return x.Team1
-	}
-	return ""
-}
-
-func (x *Match) GetTeam2() string {
-	if x != nil {
-		return x.Team2
-	}
-	return ""
-}
-
-func (x *Match) GetTime() *timestamp.Timestamp {
-	if x != nil {
-		return x.Time
-	}
-	return nil
-}
-
-var File_sports_proto protoreflect.FileDescriptor
-
-var file_sports_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
-	0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x41, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x4d,
-	0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a,
-	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x3d, 0x0a, 0x0b, 0x4d, 0x61,
-	0x74, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61,
-	0x64, 0x69, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x61, 0x64,
-	0x69, 0x75, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x3e, 0x0a, 0x13, 0x4c, 0x69, 0x73,
-	0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x27, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68,
-	0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x30, 0x0a, 0x13, 0x47, 0x65, 0x74,
-	0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x22, 0x3b, 0x0a, 0x14, 0x47,
-	0x65, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x22, 0xb7, 0x01, 0x0a, 0x05, 0x4d, 0x61, 0x74,
-	0x63, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x64, 0x69, 0x75,
-	0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x61, 0x64, 0x69, 0x75, 0x6d,
-	0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x31, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x31, 0x12, 0x14, 0x0a, 0x05,
-	0x74, 0x65, 0x61, 0x6d, 0x32, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x65, 0x61,
-	0x6d, 0x32, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69,
-	0x6d, 0x65, 0x32, 0x9f, 0x01, 0x0a, 0x06, 0x53, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x48, 0x0a,
-	0x0b, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x73,
-	0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74,
-	0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4d, 0x61,
-	0x74, 0x63, 0x68, 0x42, 0x79, 0x49, 0x44, 0x12, 0x1b, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73,
-	0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x2e, 0x47, 0x65,
-	0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x42, 0x09, 0x5a, 0x07, 0x2f, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_sports_proto_rawDescOnce sync.Once
-	file_sports_proto_rawDescData = file_sports_proto_rawDesc
-)
-
-func file_sports_proto_rawDescGZIP() []byte {
-	file_sports_proto_rawDescOnce.Do(func() {
-		file_sports_proto_rawDescData = protoimpl.X.CompressGZIP(file_sports_proto_rawDescData)
-	})
-	return file_sports_proto_rawDescData
-}
-
-var file_sports_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_sports_proto_goTypes = []interface{}{
-	(*ListMatchesRequest)(nil),   // 0: sports.ListMatchesRequest
-	(*MatchFilter)(nil),          // 1: sports.MatchFilter
-	(*ListMatchesResponse)(nil),  // 2: sports.ListMatchesResponse
-	(*GetMatchByIDRequest)(nil),  // 3: sports.GetMatchByIDRequest
-	(*GetMatchByIDResponse)(nil), // 4: sports.GetMatchByIDResponse
-	(*Match)(nil),                // 5: sports.Match
-	(*timestamp.Timestamp)(nil),  // 6: google.protobuf.Timestamp
-}
-var file_sports_proto_depIdxs = []int32{
-	1, // 0: sports.ListMatchesRequest.filter:type_name -> sports.MatchFilter
-	5, // 1: sports.ListMatchesResponse.matches:type_name -> sports.Match
-	5, // 2: sports.GetMatchByIDResponse.match:type_name -> sports.Match
-	6, // 3: sports.Match.time:type_name -> google.protobuf.Timestamp
-	0, // 4: sports.Sports.ListMatches:input_type -> sports.ListMatchesRequest
-	3, // 5: sports.Sports.GetMatchByID:input_type -> sports.GetMatchByIDRequest
-	2, // 6: sports.Sports.ListMatches:output_type -> sports.ListMatchesResponse
-	4, // 7: sports.Sports.GetMatchByID:output_type -> sports.GetMatchByIDResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
-}
-
-func init() { file_sports_proto_init() }
-func file_sports_proto_init() {
-	if File_sports_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_sports_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListMatchesRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_sports_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MatchFilter); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_sports_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListMatchesResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_sports_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetMatchByIDRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_sports_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetMatchByIDResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_sports_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Match); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_sports_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_sports_proto_goTypes,
-		DependencyIndexes: file_sports_proto_depIdxs,
-		MessageInfos:      file_sports_proto_msgTypes,
-	}.Build()
-	File_sports_proto = out.File
-	file_sports_proto_rawDesc = nil
-	file_sports_proto_goTypes = nil
-	file_sports_proto_depIdxs = nil
-}
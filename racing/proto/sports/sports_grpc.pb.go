@@ -1,141 +0,0 @@
-// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
-
-package sports
-
-import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-)
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.32.0 or later.
-const _ = grpc.SupportPackageIsVersion7
-
-// SportsClient is the client API for Sports service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type SportsClient interface {
-	// ListMatches returns a collection of all matches.
-	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error)
-	// GetMatchByID returns a single match by its ID.
-	GetMatchByID(ctx context.Context, in *GetMatchByIDRequest, opts ...grpc.CallOption) (*GetMatchByIDResponse, error)
-}
-
-type sportsClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewSportsClient(cc grpc.ClientConnInterface) SportsClient {
-	return &sportsClient{cc}
-}
-
-func (c *sportsClient) ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error) {
-	out := new(ListMatchesResponse)
-	err := c.cc.Invoke(ctx, "/sports.Sports/ListMatches", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *sportsClient) GetMatchByID(ctx context.Context, in *GetMatchByIDRequest, opts ...grpc.CallOption) (*GetMatchByIDResponse, error) {
-	out := new(GetMatchByIDResponse)
-	err := c.cc.Invoke(ctx, "/sports.Sports/GetMatchByID", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-// SportsServer is the server API for Sports service.
-// All implementations must embed UnimplementedSportsServer
-// for forward compatibility
-type SportsServer interface {
-	// ListMatches returns a collection of all matches.
-	ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error)
-	// GetMatchByID returns a single match by its ID.
-	GetMatchByID(context.Context, *GetMatchByIDRequest) (*GetMatchByIDResponse, error)
-	mustEmbedUnimplementedSportsServer()
-}
-
-// UnimplementedSportsServer must be embedded to have forward compatible implementations.
-type UnimplementedSportsServer struct {
-}
-
-func (UnimplementedSportsServer) ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
-}
-func (UnimplementedSportsServer) GetMatchByID(context.Context, *GetMatchByIDRequest) (*GetMatchByIDResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetMatchByID not implemented")
-}
-func (UnimplementedSportsServer) mustEmbedUnimplementedSportsServer() {}
-
-// UnsafeSportsServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to SportsServer will
-// result in compilation errors.
-type UnsafeSportsServer interface {
-	mustEmbedUnimplementedSportsServer()
-}
-
-func RegisterSportsServer(s grpc.ServiceRegistrar, srv SportsServer) {
-	s.RegisterService(&Sports_ServiceDesc, srv)
-}
-
-func _Sports_ListMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListMatchesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SportsServer).ListMatches(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/sports.Sports/ListMatches",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SportsServer).ListMatches(ctx, req.(*ListMatchesRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Sports_GetMatchByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetMatchByIDRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SportsServer).GetMatchByID(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/sports.Sports/GetMatchByID",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SportsServer).GetMatchByID(ctx, req.(*GetMatchByIDRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-// Sports_ServiceDesc is the grpc.ServiceDesc for Sports service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Sports_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "sports.Sports",
-	HandlerType: (*SportsServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ListMatches",
-			Handler:    _Sports_ListMatches_Handler,
-		},
-		{
-			MethodName: "GetMatchByID",
-			Handler:    _Sports_GetMatchByID_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "sports.proto",
-}
@@ -2,13 +2,26 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/golang/protobuf/ptypes"
 	"testing"
 	"time"
 
-	"github.com/Kim-Hardie/entain-master/racing/proto/racing"
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/Kim-Hardie/entain-master/racing/db"
+	"github.com/Kim-Hardie/entain-master/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 //Testify Mock classes, used for its similarity with Java Mock classes im used to using.
@@ -22,16 +35,102 @@ func (m *MockRacesRepo) Init() error {
 	return args.Error(0)
 }
 
-func (m *MockRacesRepo) List(filter *racing.ListRacesRequestFilter) ([]*racing.Race, error) {
-	args := m.Called(filter)
+func (m *MockRacesRepo) List(ctx context.Context, filter *racing.ListRacesRequestFilter, opts db.ListOptions) ([]*racing.Race, string, error) {
+	args := m.Called(ctx, filter, opts)
+	return args.Get(0).([]*racing.Race), args.String(1), args.Error(2)
+}
+
+func (m *MockRacesRepo) GetByID(ctx context.Context, id int64) (*racing.Race, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*racing.Race), args.Error(1)
+}
+
+func (m *MockRacesRepo) GetByIDWithFields(ctx context.Context, id int64, fields []string) (*racing.Race, error) {
+	args := m.Called(ctx, id, fields)
+	return args.Get(0).(*racing.Race), args.Error(1)
+}
+
+func (m *MockRacesRepo) BatchGet(ctx context.Context, ids []int64) ([]*racing.Race, error) {
+	args := m.Called(ctx, ids)
 	return args.Get(0).([]*racing.Race), args.Error(1)
 }
 
-func (m *MockRacesRepo) GetByID(id int64) (*racing.Race, error) {
-	args := m.Called(id)
+func (m *MockRacesRepo) Search(ctx context.Context, query string, limit int32) ([]*racing.RaceSearchResult, error) {
+	args := m.Called(ctx, query, limit)
+	return args.Get(0).([]*racing.RaceSearchResult), args.Error(1)
+}
+
+func (m *MockRacesRepo) TransitionStatus(ctx context.Context, id int64, status racing.RaceStatus, reason string) (*racing.Race, *racing.Race, error) {
+	args := m.Called(ctx, id, status, reason)
+	return args.Get(0).(*racing.Race), args.Get(1).(*racing.Race), args.Error(2)
+}
+
+func (m *MockRacesRepo) Create(ctx context.Context, race *racing.Race) (*racing.Race, error) {
+	args := m.Called(ctx, race)
 	return args.Get(0).(*racing.Race), args.Error(1)
 }
 
+func (m *MockRacesRepo) Update(ctx context.Context, race *racing.Race, fields []string) (*racing.Race, *racing.Race, error) {
+	args := m.Called(ctx, race, fields)
+	return args.Get(0).(*racing.Race), args.Get(1).(*racing.Race), args.Error(2)
+}
+
+func (m *MockRacesRepo) Delete(ctx context.Context, id int64, force bool) (*racing.Race, error) {
+	args := m.Called(ctx, id, force)
+	return args.Get(0).(*racing.Race), args.Error(1)
+}
+
+func (m *MockRacesRepo) SubmitResult(ctx context.Context, id int64, placings []*racing.Placing, protestPending bool) (*racing.Race, *racing.Race, *racing.RaceResult, error) {
+	args := m.Called(ctx, id, placings, protestPending)
+	return args.Get(0).(*racing.Race), args.Get(1).(*racing.Race), args.Get(2).(*racing.RaceResult), args.Error(3)
+}
+
+func (m *MockRacesRepo) ListJumped(ctx context.Context, from, to time.Time) ([]*racing.Race, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]*racing.Race), args.Error(1)
+}
+
+func (m *MockRacesRepo) GetResult(ctx context.Context, id int64) (*racing.RaceResult, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*racing.RaceResult), args.Error(1)
+}
+
+func (m *MockRacesRepo) SetVisibility(ctx context.Context, ids []int64, visible bool, actor, reason string) ([]*racing.Race, []*racing.Race, error) {
+	args := m.Called(ctx, ids, visible, actor, reason)
+	return args.Get(0).([]*racing.Race), args.Get(1).([]*racing.Race), args.Error(2)
+}
+
+func (m *MockRacesRepo) ListAudit(ctx context.Context, filter *racing.ListRaceAuditRequestFilter, pageSize int32, pageToken string) ([]*racing.RaceAuditEntry, string, error) {
+	args := m.Called(ctx, filter, pageSize, pageToken)
+	return args.Get(0).([]*racing.RaceAuditEntry), args.String(1), args.Error(2)
+}
+
+type MockMeetingsRepo struct {
+	mock.Mock
+}
+
+func (m *MockMeetingsRepo) List(ctx context.Context, filter *racing.ListMeetingsRequestFilter, pageSize int32, pageToken string) ([]*racing.Meeting, string, error) {
+	args := m.Called(ctx, filter, pageSize, pageToken)
+	return args.Get(0).([]*racing.Meeting), args.String(1), args.Error(2)
+}
+
+func (m *MockMeetingsRepo) GetByID(ctx context.Context, id int64) (*racing.Meeting, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*racing.Meeting), args.Error(1)
+}
+
+type MockRunnersRepo struct {
+	mock.Mock
+}
+
+func (m *MockRunnersRepo) List(ctx context.Context, raceID int64) ([]*racing.Runner, error) {
+	args := m.Called(ctx, raceID)
+	return args.Get(0).([]*racing.Runner), args.Error(1)
+}
+
+// defaultListOptions are the options the service passes when a request doesn't ask for a page.
+var defaultListOptions = db.ListOptions{PageSize: storage.DefaultPageSize}
+
 func TestListRaces(t *testing.T) {
 	raceTime, _ := ptypes.TimestampProto(time.Now())
 	// Sample races to be used for testing
@@ -56,12 +155,12 @@ func TestListRaces(t *testing.T) {
 
 	//Mock the repository and Create new Racing Service
 	mockRepo := new(MockRacesRepo)
-	s := NewRacingService(mockRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
 
 	//Set expected outputs for List function Mock
-	mockRepo.On("List", &racing.ListRacesRequestFilter{ShowOnlyVisible: &[]bool{true}[0]}).Return([]*racing.Race{races[0]}, nil)
-	mockRepo.On("List", &racing.ListRacesRequestFilter{ShowOnlyVisible: &[]bool{false}[0]}).Return(races, nil)
-	mockRepo.On("List", &racing.ListRacesRequestFilter{}).Return([]*racing.Race{races[0]}, nil)
+	mockRepo.On("List", mock.Anything, &racing.ListRacesRequestFilter{ShowOnlyVisible: &[]bool{true}[0]}, defaultListOptions).Return([]*racing.Race{races[0]}, "", nil)
+	mockRepo.On("List", mock.Anything, &racing.ListRacesRequestFilter{ShowOnlyVisible: &[]bool{false}[0]}, defaultListOptions).Return(races, "", nil)
+	mockRepo.On("List", mock.Anything, &racing.ListRacesRequestFilter{}, defaultListOptions).Return([]*racing.Race{races[0]}, "", nil)
 
 	tests := []struct {
 		name    string
@@ -142,11 +241,11 @@ func TestListRaces_OrderAscending(t *testing.T) {
 
 	// Mock the repository and create a new Racing Service
 	mockRepo := new(MockRacesRepo)
-	s := NewRacingService(mockRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
 
 	// Set expected outputs for List function mock
-	mockRepo.On("List", &racing.ListRacesRequestFilter{OrderAscending: &[]bool{true}[0]}).Return([]*racing.Race{races[0], races[1]}, nil)
-	mockRepo.On("List", &racing.ListRacesRequestFilter{OrderAscending: &[]bool{false}[0]}).Return([]*racing.Race{races[1], races[0]}, nil)
+	mockRepo.On("List", mock.Anything, &racing.ListRacesRequestFilter{OrderAscending: &[]bool{true}[0]}, defaultListOptions).Return([]*racing.Race{races[0], races[1]}, "", nil)
+	mockRepo.On("List", mock.Anything, &racing.ListRacesRequestFilter{OrderAscending: &[]bool{false}[0]}, defaultListOptions).Return([]*racing.Race{races[1], races[0]}, "", nil)
 
 	tests := []struct {
 		name          string
@@ -187,6 +286,98 @@ func TestListRaces_OrderAscending(t *testing.T) {
 	}
 }
 
+// Test ListRaces page_size handling and page token/order_by pass-through
+func TestListRaces_Pagination(t *testing.T) {
+	raceTime, _ := ptypes.TimestampProto(time.Now())
+	races := []*racing.Race{
+		{Id: 1, Name: "Test Race 1", Visible: true, AdvertisedStartTime: raceTime},
+		{Id: 2, Name: "Test Race 2", Visible: true, AdvertisedStartTime: raceTime},
+	}
+
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	filter := &racing.ListRacesRequestFilter{}
+	mockRepo.On("List", mock.Anything, filter, db.ListOptions{PageSize: 1}).Return(races[:1], "next", nil)
+	mockRepo.On("List", mock.Anything, filter, db.ListOptions{PageSize: 1, PageToken: "next"}).Return(races[1:], "", nil)
+	mockRepo.On("List", mock.Anything, filter, db.ListOptions{PageSize: storage.MaxPageSize}).Return(races, "", nil)
+	mockRepo.On("List", mock.Anything, filter, db.ListOptions{PageSize: 1, PageToken: "bad"}).Return([]*racing.Race(nil), "", db.ErrInvalidPageToken)
+	mockRepo.On("List", mock.Anything, filter, db.ListOptions{PageSize: 1, OrderBy: "name desc"}).Return(races[1:], "", nil)
+	mockRepo.On("List", mock.Anything, filter, db.ListOptions{PageSize: 1, OrderBy: "colour"}).Return([]*racing.Race(nil), "", db.ErrInvalidOrderBy)
+
+	tests := []struct {
+		name          string
+		req           *racing.ListRacesRequest
+		wantCode      codes.Code
+		wantRaces     []*racing.Race
+		wantNextToken string
+	}{
+		{
+			name:          "First page",
+			req:           &racing.ListRacesRequest{Filter: filter, PageSize: 1},
+			wantRaces:     races[:1],
+			wantNextToken: "next",
+		},
+		{
+			name:      "Last page",
+			req:       &racing.ListRacesRequest{Filter: filter, PageSize: 1, PageToken: "next"},
+			wantRaces: races[1:],
+		},
+		{
+			name:      "Page size is capped",
+			req:       &racing.ListRacesRequest{Filter: filter, PageSize: storage.MaxPageSize + 1},
+			wantRaces: races,
+		},
+		{
+			name:     "Negative page size",
+			req:      &racing.ListRacesRequest{Filter: filter, PageSize: -1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Invalid page token",
+			req:      &racing.ListRacesRequest{Filter: filter, PageSize: 1, PageToken: "bad"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Unspecified status in filter",
+			req:      &racing.ListRacesRequest{Filter: &racing.ListRacesRequestFilter{Statuses: []racing.RaceStatus{racing.RaceStatus_RACE_STATUS_UNSPECIFIED}}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Empty start time window",
+			req: &racing.ListRacesRequest{Filter: &racing.ListRacesRequestFilter{
+				StartTimeFrom: timestamppb.New(time.Now()),
+				StartTimeTo:   timestamppb.New(time.Now().Add(-time.Hour)),
+			}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:      "Order by is passed through",
+			req:       &racing.ListRacesRequest{Filter: filter, PageSize: 1, OrderBy: "name desc"},
+			wantRaces: races[1:],
+		},
+		{
+			name:     "Invalid order by",
+			req:      &racing.ListRacesRequest{Filter: filter, PageSize: 1, OrderBy: "colour"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ListRaces(context.Background(), tt.req)
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRaces, resp.Races)
+			assert.Equal(t, tt.wantNextToken, resp.NextPageToken)
+		})
+	}
+}
+
 //Test get race by ID
 func TestGetRaceByID(t *testing.T) {
 	raceTime, _ := ptypes.TimestampProto(time.Now())
@@ -203,30 +394,998 @@ func TestGetRaceByID(t *testing.T) {
 
 	// Mock the repository and create a new Racing Service
 	mockRepo := new(MockRacesRepo)
-	s := NewRacingService(mockRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
 
 	// Set the expected behavior for GetByID method
-	mockRepo.On("GetByID", int64(1)).Return(race, nil)
+	mockRepo.On("GetByIDWithFields", mock.Anything, int64(1), []string(nil)).Return(race, nil)
+	mockRepo.On("GetByIDWithFields", mock.Anything, int64(2), []string(nil)).Return((*racing.Race)(nil), fmt.Errorf("race 2: %w", db.ErrNotFound))
 
 	tests := []struct {
-		name        string
-		raceID      int64
-		expected    *racing.Race
-		expectedErr error
+		name         string
+		raceID       int64
+		expected     *racing.Race
+		expectedCode codes.Code
 	}{
 		{
-			name:        "Valid race ID",
-			raceID:      1,
-			expected:    race,
-			expectedErr: nil,
+			name:         "Valid race ID",
+			raceID:       1,
+			expected:     race,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Unknown race ID",
+			raceID:       2,
+			expected:     nil,
+			expectedCode: codes.NotFound,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			resp, err := s.GetRaceByID(context.Background(), &racing.GetRaceByIDRequest{RaceId: tt.raceID})
-			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
 			assert.Equal(t, tt.expected, resp.GetRace())
 		})
 	}
 }
+
+// Test TransitionRaceStatus validation and error mapping
+func TestTransitionRaceStatus(t *testing.T) {
+	race := &racing.Race{Id: 1, Name: "Test Race", RaceStatus: racing.RaceStatus_SUSPENDED}
+
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	mockRepo.On("TransitionStatus", mock.Anything, int64(1), racing.RaceStatus_SUSPENDED, "late scratching").Return(&racing.Race{Id: 1, Name: "Test Race", RaceStatus: racing.RaceStatus_OPEN}, race, nil)
+	mockRepo.On("TransitionStatus", mock.Anything, int64(1), racing.RaceStatus_RESULTED, "").Return((*racing.Race)(nil), (*racing.Race)(nil), db.ErrIllegalTransition)
+	mockRepo.On("TransitionStatus", mock.Anything, int64(2), racing.RaceStatus_CLOSED, "").Return((*racing.Race)(nil), (*racing.Race)(nil), fmt.Errorf("race 2: %w", db.ErrNotFound))
+
+	tests := []struct {
+		name     string
+		req      *racing.TransitionRaceStatusRequest
+		wantCode codes.Code
+		wantRace *racing.Race
+	}{
+		{
+			name:     "Legal transition",
+			req:      &racing.TransitionRaceStatusRequest{RaceId: 1, Status: racing.RaceStatus_SUSPENDED, Reason: "late scratching"},
+			wantRace: race,
+		},
+		{
+			name:     "Illegal transition",
+			req:      &racing.TransitionRaceStatusRequest{RaceId: 1, Status: racing.RaceStatus_RESULTED},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "Unknown race",
+			req:      &racing.TransitionRaceStatusRequest{RaceId: 2, Status: racing.RaceStatus_CLOSED},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Unspecified status",
+			req:      &racing.TransitionRaceStatusRequest{RaceId: 1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Unknown status",
+			req:      &racing.TransitionRaceStatusRequest{RaceId: 1, Status: racing.RaceStatus(42)},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.TransitionRaceStatus(context.Background(), tt.req)
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRace, resp.GetRace())
+		})
+	}
+}
+
+// Test repository errors map onto gRPC codes with ErrorInfo details
+func TestGetRaceByID_Errors(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	mockRepo.On("GetByIDWithFields", mock.Anything, int64(1), []string(nil)).Return((*racing.Race)(nil), fmt.Errorf("race 1: %w", db.ErrNotFound))
+	mockRepo.On("GetByIDWithFields", mock.Anything, int64(2), []string(nil)).Return((*racing.Race)(nil), fmt.Errorf("%w: database is locked", db.ErrUnavailable))
+	mockRepo.On("GetByIDWithFields", mock.Anything, int64(3), []string(nil)).Return((*racing.Race)(nil), errors.New("disk on fire"))
+	mockRepo.On("GetByIDWithFields", mock.Anything, int64(4), []string(nil)).Return((*racing.Race)(nil), context.DeadlineExceeded)
+
+	tests := []struct {
+		name       string
+		raceID     int64
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{name: "Not found", raceID: 1, wantCode: codes.NotFound, wantReason: reasonRaceNotFound},
+		{name: "Storage unavailable", raceID: 2, wantCode: codes.Unavailable, wantReason: reasonStorageUnavailable},
+		{name: "Unexpected error", raceID: 3, wantCode: codes.Internal},
+		{name: "Deadline exceeded", raceID: 4, wantCode: codes.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.GetRaceByID(context.Background(), &racing.GetRaceByIDRequest{RaceId: tt.raceID})
+			st := status.Convert(err)
+			assert.Equal(t, tt.wantCode, st.Code())
+
+			if tt.wantReason == "" {
+				assert.Empty(t, st.Details())
+				return
+			}
+
+			require.Len(t, st.Details(), 1)
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantReason, info.Reason)
+			assert.Equal(t, errorDomain, info.Domain)
+			assert.Equal(t, fmt.Sprint(tt.raceID), info.Metadata["race_id"])
+		})
+	}
+}
+
+// Test include_meeting is passed through to the repository
+func TestListRaces_IncludeMeeting(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	races := []*racing.Race{{Id: 1, MeetingId: 7, Meeting: &racing.Meeting{Id: 7, Venue: "Flemington"}}}
+	opts := defaultListOptions
+	opts.IncludeMeeting = true
+	mockRepo.On("List", mock.Anything, (*racing.ListRacesRequestFilter)(nil), opts).Return(races, "", nil)
+
+	resp, err := s.ListRaces(context.Background(), &racing.ListRacesRequest{IncludeMeeting: true})
+	require.NoError(t, err)
+	assert.Equal(t, races, resp.Races)
+	mockRepo.AssertExpectations(t)
+}
+
+// Categories are passed to the repository once they are known race types.
+func TestListRaces_Categories(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	greyhounds := &racing.ListRacesRequestFilter{Categories: []racing.RaceType{racing.RaceType_GREYHOUND}}
+	races := []*racing.Race{{Id: 1, Category: racing.RaceType_GREYHOUND}}
+	mockRepo.On("List", mock.Anything, greyhounds, defaultListOptions).Return(races, "", nil)
+
+	resp, err := s.ListRaces(context.Background(), &racing.ListRacesRequest{Filter: greyhounds})
+	require.NoError(t, err)
+	assert.Equal(t, races, resp.Races)
+
+	for _, category := range []racing.RaceType{racing.RaceType_RACE_TYPE_UNSPECIFIED, racing.RaceType(42)} {
+		_, err := s.ListRaces(context.Background(), &racing.ListRacesRequest{
+			Filter: &racing.ListRacesRequestFilter{Categories: []racing.RaceType{racing.RaceType_HARNESS, category}},
+		})
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		require.Len(t, st.Details(), 1)
+		assert.Equal(t, reasonInvalidRaceType, st.Details()[0].(*errdetails.ErrorInfo).Reason)
+	}
+	mockRepo.AssertNumberOfCalls(t, "List", 1)
+}
+
+// Test ListMeetings validates its filter and paging before hitting the repository
+func TestListMeetings(t *testing.T) {
+	meetings := []*racing.Meeting{{Id: 1, Venue: "Flemington", RaceType: racing.RaceType_THOROUGHBRED, Date: "2021-03-02"}}
+
+	tests := []struct {
+		name       string
+		req        *racing.ListMeetingsRequest
+		wantSize   int32
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{name: "Defaults page size", req: &racing.ListMeetingsRequest{}, wantSize: storage.DefaultPageSize},
+		{name: "Caps page size", req: &racing.ListMeetingsRequest{PageSize: 5000}, wantSize: storage.MaxPageSize},
+		{
+			name:     "Filters by race type and date",
+			req:      &racing.ListMeetingsRequest{Filter: &racing.ListMeetingsRequestFilter{RaceTypes: []racing.RaceType{racing.RaceType_HARNESS}, DateFrom: "2021-03-01", DateTo: "2021-03-08"}},
+			wantSize: storage.DefaultPageSize,
+		},
+		{name: "Negative page size", req: &racing.ListMeetingsRequest{PageSize: -1}, wantCode: codes.InvalidArgument, wantReason: reasonInvalidPageSize},
+		{
+			name:       "Unspecified race type",
+			req:        &racing.ListMeetingsRequest{Filter: &racing.ListMeetingsRequestFilter{RaceTypes: []racing.RaceType{racing.RaceType_RACE_TYPE_UNSPECIFIED}}},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInvalidRaceType,
+		},
+		{
+			name:       "Malformed date",
+			req:        &racing.ListMeetingsRequest{Filter: &racing.ListMeetingsRequestFilter{DateFrom: "2/3/2021"}},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInvalidDateWindow,
+		},
+		{
+			name:       "Empty date window",
+			req:        &racing.ListMeetingsRequest{Filter: &racing.ListMeetingsRequestFilter{DateFrom: "2021-03-02", DateTo: "2021-03-02"}},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInvalidDateWindow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMeetingsRepo)
+			s := NewRacingService(new(MockRacesRepo), mockRepo, new(MockRunnersRepo))
+			mockRepo.On("List", mock.Anything, tt.req.Filter, tt.wantSize, "").Return(meetings, "", nil)
+
+			resp, err := s.ListMeetings(context.Background(), tt.req)
+			st := status.Convert(err)
+			assert.Equal(t, tt.wantCode, st.Code())
+
+			if tt.wantCode != codes.OK {
+				require.Len(t, st.Details(), 1)
+				assert.Equal(t, tt.wantReason, st.Details()[0].(*errdetails.ErrorInfo).Reason)
+				mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, meetings, resp.Meetings)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Test a missing meeting is NotFound with its own reason
+func TestGetMeeting(t *testing.T) {
+	meeting := &racing.Meeting{Id: 1, Venue: "Flemington", Country: "AU", RaceType: racing.RaceType_THOROUGHBRED, Date: "2021-03-02"}
+
+	mockRepo := new(MockMeetingsRepo)
+	s := NewRacingService(new(MockRacesRepo), mockRepo, new(MockRunnersRepo))
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(meeting, nil)
+	mockRepo.On("GetByID", mock.Anything, int64(2)).Return((*racing.Meeting)(nil), fmt.Errorf("meeting 2: %w", db.ErrNotFound))
+
+	resp, err := s.GetMeeting(context.Background(), &racing.GetMeetingRequest{MeetingId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, meeting, resp.Meeting)
+
+	_, err = s.GetMeeting(context.Background(), &racing.GetMeetingRequest{MeetingId: 2})
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	require.Len(t, st.Details(), 1)
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.Equal(t, reasonMeetingNotFound, info.Reason)
+	assert.Equal(t, "2", info.Metadata["meeting_id"])
+}
+
+// Test runners are listed, and only embedded in GetRaceByID when asked for
+func TestListRunners(t *testing.T) {
+	racesRepo := new(MockRacesRepo)
+	runnersRepo := new(MockRunnersRepo)
+	s := NewRacingService(racesRepo, new(MockMeetingsRepo), runnersRepo)
+
+	runners := []*racing.Runner{
+		{Id: 1, RaceId: 1, Number: 1, Name: "Winx", Barrier: 4, Jockey: "Hugh Bowman", Trainer: "Chris Waller", Weight: 57},
+		{Id: 2, RaceId: 1, Number: 2, Name: "Verry Elleegant", Barrier: 1, Scratched: true},
+	}
+	racesRepo.On("GetByIDWithFields", mock.Anything, int64(1), []string(nil)).Return(&racing.Race{Id: 1}, nil)
+	runnersRepo.On("List", mock.Anything, int64(1)).Return(runners, nil)
+	runnersRepo.On("List", mock.Anything, int64(2)).Return(([]*racing.Runner)(nil), fmt.Errorf("race 2: %w", db.ErrNotFound))
+
+	resp, err := s.ListRunners(context.Background(), &racing.ListRunnersRequest{RaceId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, runners, resp.Runners)
+
+	_, err = s.ListRunners(context.Background(), &racing.ListRunnersRequest{RaceId: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	race, err := s.GetRaceByID(context.Background(), &racing.GetRaceByIDRequest{RaceId: 1})
+	require.NoError(t, err)
+	assert.Empty(t, race.Race.Runners)
+	runnersRepo.AssertNumberOfCalls(t, "List", 2)
+
+	race, err = s.GetRaceByID(context.Background(), &racing.GetRaceByIDRequest{RaceId: 1, IncludeRunners: true})
+	require.NoError(t, err)
+	assert.Equal(t, runners, race.Race.Runners)
+}
+
+func TestSubmitResult(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	placings := func(positions ...int64) []*racing.Placing {
+		var placings []*racing.Placing
+		for i, position := range positions {
+			placings = append(placings, &racing.Placing{RunnerNumber: int64(i + 1), Position: position})
+		}
+		return placings
+	}
+
+	race := &racing.Race{Id: 1, RaceStatus: racing.RaceStatus_RESULTED}
+	result := &racing.RaceResult{RaceId: 1, Placings: placings(1, 1, 3)}
+	mockRepo.On("SubmitResult", mock.Anything, int64(1), mock.Anything, false).Return(&racing.Race{Id: 1, RaceStatus: racing.RaceStatus_CLOSED}, race, result, nil)
+	mockRepo.On("SubmitResult", mock.Anything, int64(2), mock.Anything, false).Return((*racing.Race)(nil), (*racing.Race)(nil), (*racing.RaceResult)(nil), db.ErrIllegalTransition)
+	mockRepo.On("SubmitResult", mock.Anything, int64(3), mock.Anything, false).Return((*racing.Race)(nil), (*racing.Race)(nil), (*racing.RaceResult)(nil), fmt.Errorf("%w: runner 1 in race 3 is scratched", db.ErrInvalidResult))
+
+	tests := []struct {
+		name       string
+		req        *racing.SubmitResultRequest
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{name: "Dead heat", req: &racing.SubmitResultRequest{RaceId: 1, Placings: placings(1, 1, 3)}},
+		{name: "Dead heat out of order", req: &racing.SubmitResultRequest{RaceId: 1, Placings: placings(4, 2, 2, 1)}},
+		{name: "No placings", req: &racing.SubmitResultRequest{RaceId: 1}, wantCode: codes.InvalidArgument, wantReason: reasonInvalidPlacings},
+		{name: "No winner", req: &racing.SubmitResultRequest{RaceId: 1, Placings: placings(2, 3)}, wantCode: codes.InvalidArgument, wantReason: reasonInvalidPlacings},
+		{name: "Position after dead heat", req: &racing.SubmitResultRequest{RaceId: 1, Placings: placings(1, 1, 2)}, wantCode: codes.InvalidArgument, wantReason: reasonInvalidPlacings},
+		{
+			name:       "Runner placed twice",
+			req:        &racing.SubmitResultRequest{RaceId: 1, Placings: []*racing.Placing{{RunnerNumber: 1, Position: 1}, {RunnerNumber: 1, Position: 2}}},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInvalidPlacings,
+		},
+		{
+			name:       "Unnumbered runner",
+			req:        &racing.SubmitResultRequest{RaceId: 1, Placings: []*racing.Placing{{Position: 1}}},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInvalidPlacings,
+		},
+		{name: "Race not closed", req: &racing.SubmitResultRequest{RaceId: 2, Placings: placings(1)}, wantCode: codes.FailedPrecondition, wantReason: reasonIllegalTransition},
+		{name: "Scratched runner", req: &racing.SubmitResultRequest{RaceId: 3, Placings: placings(1)}, wantCode: codes.InvalidArgument, wantReason: reasonInvalidPlacings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.SubmitResult(context.Background(), tt.req)
+			if tt.wantCode == codes.OK {
+				require.NoError(t, err)
+				assert.Equal(t, race, resp.Race)
+				assert.Equal(t, result, resp.Result)
+				return
+			}
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.wantCode, st.Code())
+			require.Len(t, st.Details(), 1)
+			assert.Equal(t, tt.wantReason, st.Details()[0].(*errdetails.ErrorInfo).Reason)
+		})
+	}
+}
+
+func TestGetRaceResult(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	result := &racing.RaceResult{RaceId: 1, Placings: []*racing.Placing{{RunnerNumber: 4, Position: 1}}, ProtestPending: true}
+	mockRepo.On("GetResult", mock.Anything, int64(1)).Return(result, nil)
+	mockRepo.On("GetResult", mock.Anything, int64(2)).Return((*racing.RaceResult)(nil), fmt.Errorf("race 2: %w", db.ErrNoResult))
+	mockRepo.On("GetResult", mock.Anything, int64(3)).Return((*racing.RaceResult)(nil), fmt.Errorf("race 3: %w", db.ErrNotFound))
+
+	resp, err := s.GetRaceResult(context.Background(), &racing.GetRaceResultRequest{RaceId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, result, resp.Result)
+
+	for raceID, wantReason := range map[int64]string{2: reasonResultNotFound, 3: reasonRaceNotFound} {
+		_, err := s.GetRaceResult(context.Background(), &racing.GetRaceResultRequest{RaceId: raceID})
+		st := status.Convert(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		require.Len(t, st.Details(), 1)
+		assert.Equal(t, wantReason, st.Details()[0].(*errdetails.ErrorInfo).Reason)
+	}
+}
+
+// fakeWatchStream hands the events sent on a WatchRaces stream to the test.
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *racing.RaceEvent
+}
+
+func (f *fakeWatchStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeWatchStream) Send(event *racing.RaceEvent) error {
+	f.events <- event
+	return nil
+}
+
+// watch starts a WatchRaces call, returning its stream and a channel that
+// receives the error it ends with.
+func watch(ctx context.Context, s *RacingService, req *racing.WatchRacesRequest) (*fakeWatchStream, <-chan error) {
+	stream := &fakeWatchStream{ctx: ctx, events: make(chan *racing.RaceEvent)}
+	done := make(chan error, 1)
+	go func() { done <- s.WatchRaces(req, stream) }()
+	return stream, done
+}
+
+func nextEvent(t *testing.T, stream *fakeWatchStream) *racing.RaceEvent {
+	t.Helper()
+
+	select {
+	case event := <-stream.events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a race event")
+		return nil
+	}
+}
+
+// A watch sends a snapshot, then the changes to matching races, and can be
+// resumed from any change.
+func TestWatchRaces(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+	frozen := time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return frozen }
+
+	filter := &racing.ListRacesRequestFilter{MeetingIds: []int64{1}}
+	snapshot := []*racing.Race{{Id: 1, MeetingId: 1, Visible: true}, {Id: 2, MeetingId: 1, Visible: true}}
+	mockRepo.On("List", mock.Anything, filter, db.ListOptions{PageSize: storage.MaxPageSize}).Return(snapshot, "", nil)
+
+	suspended := &racing.Race{Id: 1, MeetingId: 1, Visible: true, RaceStatus: racing.RaceStatus_SUSPENDED}
+	elsewhere := &racing.Race{Id: 3, MeetingId: 2, Visible: true, RaceStatus: racing.RaceStatus_SUSPENDED}
+	mockRepo.On("TransitionStatus", mock.Anything, int64(1), racing.RaceStatus_SUSPENDED, "").Return(&racing.Race{Id: 1, MeetingId: 1, Visible: true}, suspended, nil)
+	mockRepo.On("TransitionStatus", mock.Anything, int64(3), racing.RaceStatus_SUSPENDED, "").Return(&racing.Race{Id: 3, MeetingId: 2, Visible: true}, elsewhere, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, done := watch(ctx, s, &racing.WatchRacesRequest{Filter: filter})
+
+	for _, race := range snapshot {
+		event := nextEvent(t, stream)
+		assert.Equal(t, racing.RaceEventType_SNAPSHOT, event.Type)
+		assert.Equal(t, race, event.Race)
+		assert.Empty(t, event.ResumeToken)
+		assert.Equal(t, frozen, event.OccurredAt.AsTime())
+	}
+	complete := nextEvent(t, stream)
+	assert.Equal(t, racing.RaceEventType_SNAPSHOT_COMPLETE, complete.Type)
+	assert.NotEmpty(t, complete.ResumeToken)
+	assert.Equal(t, frozen, complete.OccurredAt.AsTime())
+
+	// Only the change to a race at meeting 1 is sent.
+	for _, raceID := range []int64{3, 1} {
+		_, err := s.TransitionRaceStatus(context.Background(), &racing.TransitionRaceStatusRequest{RaceId: raceID, Status: racing.RaceStatus_SUSPENDED})
+		require.NoError(t, err)
+	}
+	changed := nextEvent(t, stream)
+	assert.Equal(t, racing.RaceEventType_STATUS_CHANGED, changed.Type)
+	assert.Equal(t, suspended, changed.Race)
+
+	cancel()
+	assert.Equal(t, codes.Canceled, status.Code(<-done))
+
+	// Resuming replays the change without a snapshot.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	stream, _ = watch(ctx, s, &racing.WatchRacesRequest{Filter: filter, ResumeToken: complete.ResumeToken})
+	assert.Equal(t, changed, nextEvent(t, stream))
+	mockRepo.AssertNumberOfCalls(t, "List", 1)
+}
+
+func TestWatchRaces_Errors(t *testing.T) {
+	s := NewRacingService(new(MockRacesRepo), new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	tests := []struct {
+		name       string
+		req        *racing.WatchRacesRequest
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name:       "Unknown status",
+			req:        &racing.WatchRacesRequest{Filter: &racing.ListRacesRequestFilter{Statuses: []racing.RaceStatus{42}}},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInvalidRaceStatus,
+		},
+		{
+			name:       "has_result",
+			req:        &racing.WatchRacesRequest{Filter: &racing.ListRacesRequestFilter{HasResult: &[]bool{true}[0]}},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInvalidFilter,
+		},
+		{
+			name:       "Malformed resume token",
+			req:        &racing.WatchRacesRequest{ResumeToken: "not a token!"},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInvalidResumeToken,
+		},
+		{
+			name:       "Resume token from before a restart",
+			req:        &racing.WatchRacesRequest{ResumeToken: newChangeFeed(1, 1, func() time.Time { return time.Unix(0, 0) }).token(0)},
+			wantCode:   codes.OutOfRange,
+			wantReason: reasonResumeTokenExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, done := watch(context.Background(), s, tt.req)
+			st := status.Convert(<-done)
+			assert.Equal(t, tt.wantCode, st.Code())
+			require.Len(t, st.Details(), 1)
+			assert.Equal(t, tt.wantReason, st.Details()[0].(*errdetails.ErrorInfo).Reason)
+		})
+	}
+}
+
+// A watch that can't keep up is ended, and told where to resume from.
+func TestWatchRaces_Lagged(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+	s.feed = newChangeFeed(10, 1, time.Now)
+
+	_, start, err := s.feed.subscribe(matchAll, "")
+	require.NoError(t, err)
+
+	stream, done := watch(context.Background(), s, &racing.WatchRacesRequest{ResumeToken: start})
+	require.Eventually(t, func() bool {
+		s.feed.mu.Lock()
+		defer s.feed.mu.Unlock()
+		return len(s.feed.subs) == 2
+	}, time.Second, time.Millisecond)
+
+	// The stream isn't read from, so the watch falls behind.
+	for id := int64(1); id <= 3; id++ {
+		s.feed.publish(racing.RaceEventType_STATUS_CHANGED, nil, &racing.Race{Id: id, Visible: true})
+	}
+
+	var last *racing.RaceEvent
+	for {
+		select {
+		case event := <-stream.events:
+			last = event
+			continue
+		case err = <-done:
+		}
+		break
+	}
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.Equal(t, reasonWatchLagged, info.Reason)
+	require.NotNil(t, last)
+	assert.Equal(t, last.ResumeToken, info.Metadata["resume_token"])
+}
+
+// Races whose start times pass are published as closing, visible or not,
+// from the rows as stored.
+func TestPublishJumps(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	from := time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC)
+	to := from.Add(time.Second)
+
+	jumped := []*racing.Race{
+		{Id: 1, Visible: true, RaceStatus: racing.RaceStatus_OPEN},
+		{Id: 2, Visible: true, RaceStatus: racing.RaceStatus_OPEN},
+		{Id: 3, RaceStatus: racing.RaceStatus_OPEN},
+	}
+	mockRepo.On("ListJumped", mock.Anything, from, to).Return(jumped, nil)
+
+	all, _, err := s.feed.subscribe(matchAll, "")
+	require.NoError(t, err)
+	openOnly := &racing.ListRacesRequestFilter{Statuses: []racing.RaceStatus{racing.RaceStatus_OPEN}}
+	open, _, err := s.feed.subscribe(func(race *racing.Race) bool { return matchRaceFilter(openOnly, race) }, "")
+	require.NoError(t, err)
+
+	require.NoError(t, s.publishJumps(context.Background(), from, to))
+
+	events := receive(all)
+	require.Len(t, events, len(jumped))
+	for i, event := range events {
+		assert.Equal(t, racing.RaceEventType_STATUS_CHANGED, event.Type)
+		assert.Equal(t, jumped[i].Id, event.Race.Id)
+		assert.Equal(t, racing.RaceStatus_CLOSED, event.Race.RaceStatus)
+	}
+	// The stored rows are left as they were.
+	assert.Equal(t, racing.RaceStatus_OPEN, jumped[0].RaceStatus)
+
+	// A filter lists only visible races unless it says otherwise.
+	events = receive(open)
+	require.Len(t, events, 2)
+	for i, event := range events {
+		assert.Equal(t, racing.RaceEventType_REMOVED, event.Type)
+		assert.Equal(t, jumped[i].Id, event.Race.Id)
+	}
+
+	failing := new(MockRacesRepo)
+	s = NewRacingService(failing, new(MockMeetingsRepo), new(MockRunnersRepo))
+	failing.On("ListJumped", mock.Anything, from, to).Return([]*racing.Race(nil), db.ErrUnavailable)
+	assert.ErrorIs(t, s.publishJumps(context.Background(), from, to), db.ErrUnavailable)
+}
+
+// assertReason checks err is a status with code and an ErrorInfo with reason.
+func assertReason(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+
+	st := status.Convert(err)
+	assert.Equal(t, code, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, reason, st.Details()[0].(*errdetails.ErrorInfo).Reason)
+}
+
+func TestCreateRace(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	start := timestamppb.New(time.Date(2021, 3, 2, 5, 0, 0, 0, time.UTC))
+	valid := &racing.Race{MeetingId: 1, Name: "Cox Plate", Number: 9, Visible: true, AdvertisedStartTime: start}
+	created := &racing.Race{Id: 101, MeetingId: 1, Name: "Cox Plate", Number: 9, AdvertisedStartTime: start, RaceStatus: racing.RaceStatus_OPEN}
+	taken := &racing.Race{MeetingId: 1, Name: "Cox Plate", Number: 1, AdvertisedStartTime: start}
+	noMeeting := &racing.Race{MeetingId: 99, Name: "Cox Plate", Number: 1, AdvertisedStartTime: start}
+	mockRepo.On("Create", mock.Anything, valid).Return(created, nil)
+	mockRepo.On("Create", mock.Anything, taken).Return((*racing.Race)(nil), fmt.Errorf("meeting 1 already has a race 1: %w", db.ErrAlreadyExists))
+	mockRepo.On("Create", mock.Anything, noMeeting).Return((*racing.Race)(nil), fmt.Errorf("%w: meeting 99 doesn't exist", db.ErrInvalidRace))
+
+	_, before, err := s.feed.subscribe(matchAll, "")
+	require.NoError(t, err)
+
+	resp, err := s.CreateRace(context.Background(), &racing.CreateRaceRequest{Race: valid})
+	require.NoError(t, err)
+	assert.Equal(t, created, resp.Race)
+
+	// Watchers are told about the new race.
+	sub, _, err := s.feed.subscribe(matchAll, before)
+	require.NoError(t, err)
+	events := receive(sub)
+	require.Len(t, events, 1)
+	assert.Equal(t, racing.RaceEventType_CREATED, events[0].Type)
+
+	tests := []struct {
+		name       string
+		race       *racing.Race
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{name: "Missing race", wantCode: codes.InvalidArgument, wantReason: reasonInvalidRace},
+		{name: "Missing name", race: &racing.Race{MeetingId: 1, Number: 1, AdvertisedStartTime: start}, wantCode: codes.InvalidArgument, wantReason: reasonInvalidRace},
+		{name: "Missing meeting", race: &racing.Race{Name: "Cox Plate", Number: 1, AdvertisedStartTime: start}, wantCode: codes.InvalidArgument, wantReason: reasonInvalidRace},
+		{name: "Missing number", race: &racing.Race{MeetingId: 1, Name: "Cox Plate", AdvertisedStartTime: start}, wantCode: codes.InvalidArgument, wantReason: reasonInvalidRace},
+		{name: "Missing start time", race: &racing.Race{MeetingId: 1, Name: "Cox Plate", Number: 1}, wantCode: codes.InvalidArgument, wantReason: reasonInvalidRace},
+		{name: "Number taken", race: taken, wantCode: codes.AlreadyExists, wantReason: reasonRaceAlreadyExists},
+		{name: "Unknown meeting", race: noMeeting, wantCode: codes.InvalidArgument, wantReason: reasonInvalidRace},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateRace(context.Background(), &racing.CreateRaceRequest{Race: tt.race})
+			assertReason(t, err, tt.wantCode, tt.wantReason)
+		})
+	}
+}
+
+func TestUpdateRace(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	renamed := &racing.Race{Id: 1, Name: "Melbourne Cup"}
+	mockRepo.On("Update", mock.Anything, renamed, []string{"name"}).Return(&racing.Race{Id: 1, Name: "Melbourne Cupp"}, renamed, nil)
+	mockRepo.On("Update", mock.Anything, &racing.Race{Id: 2, Name: "Melbourne Cup"}, []string{"name"}).Return((*racing.Race)(nil), (*racing.Race)(nil), fmt.Errorf("race 2: %w", db.ErrNotFound))
+
+	resp, err := s.UpdateRace(context.Background(), &racing.UpdateRaceRequest{
+		Race:       renamed,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "name"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, renamed, resp.Race)
+
+	_, err = s.UpdateRace(context.Background(), &racing.UpdateRaceRequest{
+		Race:       &racing.Race{Id: 2, Name: "Melbourne Cup"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+	assertReason(t, err, codes.NotFound, reasonRaceNotFound)
+
+	// Without a mask every field is updated, so every field must be valid.
+	_, err = s.UpdateRace(context.Background(), &racing.UpdateRaceRequest{Race: renamed})
+	assertReason(t, err, codes.InvalidArgument, reasonInvalidRace)
+
+	for _, path := range []string{"status", "id", "category", "visible", "*"} {
+		_, err = s.UpdateRace(context.Background(), &racing.UpdateRaceRequest{
+			Race:       renamed,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", path}},
+		})
+		assertReason(t, err, codes.InvalidArgument, reasonInvalidUpdateMask)
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "Update", 2)
+}
+
+// A write isn't made until the write before it has been published, so
+// watches are sent changes in the order they were committed.
+func TestWritesPublishInOrder(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	sub, _, err := s.feed.subscribe(matchAll, "")
+	require.NoError(t, err)
+
+	open := &racing.Race{Id: 1, Name: "Melbourne Cup", RaceStatus: racing.RaceStatus_OPEN}
+	renamed := &racing.Race{Id: 1, Name: "Cox Plate", RaceStatus: racing.RaceStatus_OPEN}
+	suspended := &racing.Race{Id: 1, Name: "Cox Plate", RaceStatus: racing.RaceStatus_SUSPENDED}
+
+	updating, release := make(chan struct{}), make(chan struct{})
+	mockRepo.On("Update", mock.Anything, renamed, []string{"name"}).Run(func(mock.Arguments) {
+		close(updating)
+		<-release
+	}).Return(open, renamed, nil)
+	transitioning := make(chan struct{})
+	mockRepo.On("TransitionStatus", mock.Anything, int64(1), racing.RaceStatus_SUSPENDED, "").Run(func(mock.Arguments) {
+		close(transitioning)
+	}).Return(renamed, suspended, nil)
+
+	updated := make(chan error)
+	go func() {
+		_, err := s.UpdateRace(context.Background(), &racing.UpdateRaceRequest{Race: renamed, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}}})
+		updated <- err
+	}()
+	<-updating
+
+	transitioned := make(chan error)
+	go func() {
+		_, err := s.TransitionRaceStatus(context.Background(), &racing.TransitionRaceStatusRequest{RaceId: 1, Status: racing.RaceStatus_SUSPENDED})
+		transitioned <- err
+	}()
+
+	select {
+	case <-transitioning:
+		t.Fatal("transition made while an update was unpublished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-updated)
+	require.NoError(t, <-transitioned)
+
+	assert.Equal(t, racing.RaceEventType_UPDATED, (<-sub.events).Type)
+	event := <-sub.events
+	assert.Equal(t, racing.RaceEventType_STATUS_CHANGED, event.Type)
+	assert.Equal(t, racing.RaceStatus_SUSPENDED, event.Race.RaceStatus)
+}
+
+func TestDeleteRace(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	mockRepo.On("Delete", mock.Anything, int64(1), false).Return((*racing.Race)(nil), fmt.Errorf("race 1: %w", db.ErrRaceHasChildren))
+	mockRepo.On("Delete", mock.Anything, int64(1), true).Return(&racing.Race{Id: 1}, nil)
+	mockRepo.On("Delete", mock.Anything, int64(2), false).Return((*racing.Race)(nil), fmt.Errorf("race 2: %w", db.ErrNotFound))
+
+	_, err := s.DeleteRace(context.Background(), &racing.DeleteRaceRequest{RaceId: 1})
+	assertReason(t, err, codes.FailedPrecondition, reasonRaceHasChildren)
+
+	_, err = s.DeleteRace(context.Background(), &racing.DeleteRaceRequest{RaceId: 1, Force: true})
+	require.NoError(t, err)
+
+	_, err = s.DeleteRace(context.Background(), &racing.DeleteRaceRequest{RaceId: 2})
+	assertReason(t, err, codes.NotFound, reasonRaceNotFound)
+}
+
+func TestSetRaceVisibility(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-actor", "jo"))
+	hidden := []*racing.Race{{Id: 3}, {Id: 1}}
+	shown := []*racing.Race{{Id: 3, Visible: true}, {Id: 1, Visible: true}}
+	mockRepo.On("SetVisibility", mock.Anything, []int64{3, 1}, false, "jo", "abandoned meeting").Return(shown, hidden, nil)
+	mockRepo.On("SetVisibility", mock.Anything, []int64{1000}, true, "jo", "typo").Return([]*racing.Race(nil), []*racing.Race(nil), fmt.Errorf("races [1000]: %w", db.ErrNotFound))
+
+	sub, _, err := s.feed.subscribe(matchAll, "")
+	require.NoError(t, err)
+	visibleOnly := &racing.ListRacesRequestFilter{ShowOnlyVisible: proto.Bool(true)}
+	visibleSub, _, err := s.feed.subscribe(func(race *racing.Race) bool { return matchRaceFilter(visibleOnly, race) }, "")
+	require.NoError(t, err)
+
+	resp, err := s.SetRaceVisibility(ctx, &racing.SetRaceVisibilityRequest{RaceIds: []int64{3, 1, 3}, Reason: "abandoned meeting"})
+	require.NoError(t, err)
+	assert.Equal(t, hidden, resp.Races)
+
+	events := receive(sub)
+	require.Len(t, events, len(hidden))
+	for i, event := range events {
+		assert.Equal(t, racing.RaceEventType_UPDATED, event.Type)
+		assert.Equal(t, hidden[i], event.Race)
+	}
+
+	// Watchers of visible races are told to stop showing them.
+	events = receive(visibleSub)
+	require.Len(t, events, len(hidden))
+	for i, event := range events {
+		assert.Equal(t, racing.RaceEventType_REMOVED, event.Type)
+		assert.Equal(t, hidden[i], event.Race)
+	}
+
+	_, err = s.SetRaceVisibility(ctx, &racing.SetRaceVisibilityRequest{RaceIds: []int64{1000}, Visible: true, Reason: "typo"})
+	assertReason(t, err, codes.NotFound, reasonRaceNotFound)
+
+	tooMany := make([]int64, maxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		req    *racing.SetRaceVisibilityRequest
+		code   codes.Code
+		reason string
+	}{
+		{"no actor", context.Background(), &racing.SetRaceVisibilityRequest{RaceIds: []int64{1}, Reason: "r"}, codes.Unauthenticated, reasonActorRequired},
+		{"blank actor", metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-actor", " ")), &racing.SetRaceVisibilityRequest{RaceIds: []int64{1}, Reason: "r"}, codes.Unauthenticated, reasonActorRequired},
+		{"no races", ctx, &racing.SetRaceVisibilityRequest{Reason: "r"}, codes.InvalidArgument, reasonInvalidBatch},
+		{"bad race id", ctx, &racing.SetRaceVisibilityRequest{RaceIds: []int64{1, 0}, Reason: "r"}, codes.InvalidArgument, reasonInvalidBatch},
+		{"too many races", ctx, &racing.SetRaceVisibilityRequest{RaceIds: tooMany, Reason: "r"}, codes.InvalidArgument, reasonInvalidBatch},
+		{"no reason", ctx, &racing.SetRaceVisibilityRequest{RaceIds: []int64{1}, Reason: "  "}, codes.InvalidArgument, reasonReasonRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.SetRaceVisibility(tt.ctx, tt.req)
+			assertReason(t, err, tt.code, tt.reason)
+		})
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "SetVisibility", 2)
+}
+
+func TestListRaceAudit(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	filter := &racing.ListRaceAuditRequestFilter{RaceIds: []int64{1}}
+	entries := []*racing.RaceAuditEntry{{Id: 2, RaceId: 1, Actor: "jo"}}
+	mockRepo.On("ListAudit", mock.Anything, filter, int32(storage.DefaultPageSize), "").Return(entries, "next", nil)
+	mockRepo.On("ListAudit", mock.Anything, filter, int32(storage.MaxPageSize), "bad").Return([]*racing.RaceAuditEntry(nil), "", db.ErrInvalidPageToken)
+
+	resp, err := s.ListRaceAudit(context.Background(), &racing.ListRaceAuditRequest{Filter: filter})
+	require.NoError(t, err)
+	assert.Equal(t, entries, resp.Entries)
+	assert.Equal(t, "next", resp.NextPageToken)
+
+	_, err = s.ListRaceAudit(context.Background(), &racing.ListRaceAuditRequest{Filter: filter, PageSize: 5000, PageToken: "bad"})
+	assertReason(t, err, codes.InvalidArgument, reasonInvalidPageToken)
+
+	_, err = s.ListRaceAudit(context.Background(), &racing.ListRaceAuditRequest{PageSize: -1})
+	assertReason(t, err, codes.InvalidArgument, reasonInvalidPageSize)
+}
+
+func TestBatchGetRaces(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	mockRepo.On("BatchGet", mock.Anything, []int64{7, 1000, 3, 2000}).Return([]*racing.Race{{Id: 7}, nil, {Id: 3}, nil}, nil)
+	mockRepo.On("BatchGet", mock.Anything, []int64{1}).Return([]*racing.Race(nil), fmt.Errorf("%w: database is locked", db.ErrUnavailable))
+
+	resp, err := s.BatchGetRaces(context.Background(), &racing.BatchGetRacesRequest{RaceIds: []int64{7, 1000, 3, 7, 2000}})
+	require.NoError(t, err)
+	assert.Equal(t, []*racing.Race{{Id: 7}, {Id: 3}}, resp.Races)
+	assert.Equal(t, []int64{1000, 2000}, resp.MissingRaceIds)
+
+	_, err = s.BatchGetRaces(context.Background(), &racing.BatchGetRacesRequest{RaceIds: []int64{1}})
+	assertReason(t, err, codes.Unavailable, reasonStorageUnavailable)
+
+	tooMany := make([]int64, maxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+
+	// Duplicates count towards the limit.
+	duplicates := make([]int64, maxBatchSize+1)
+	for i := range duplicates {
+		duplicates[i] = 7
+	}
+
+	for _, ids := range [][]int64{nil, {1, -1}, tooMany, duplicates} {
+		_, err = s.BatchGetRaces(context.Background(), &racing.BatchGetRacesRequest{RaceIds: ids})
+		assertReason(t, err, codes.InvalidArgument, reasonInvalidBatch)
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "BatchGet", 2)
+}
+
+func TestSearchRaces(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	results := []*racing.RaceSearchResult{{Race: &racing.Race{Id: 4, Name: "Cox Plate"}, Snippet: "<mark>Cox</mark> Plate", Score: 1.5}}
+	mockRepo.On("Search", mock.Anything, "cox", int32(storage.DefaultPageSize)).Return(results, nil)
+	mockRepo.On("Search", mock.Anything, "cox", int32(storage.MaxPageSize)).Return([]*racing.RaceSearchResult(nil), db.ErrSearchUnavailable)
+	mockRepo.On("Search", mock.Anything, "--", int32(10)).Return([]*racing.RaceSearchResult(nil), fmt.Errorf("%w: no words", db.ErrInvalidQuery))
+
+	resp, err := s.SearchRaces(context.Background(), &racing.SearchRacesRequest{Query: "cox"})
+	require.NoError(t, err)
+	assert.Equal(t, results, resp.Results)
+
+	_, err = s.SearchRaces(context.Background(), &racing.SearchRacesRequest{Query: "cox", PageSize: storage.MaxPageSize + 1})
+	assertReason(t, err, codes.Unimplemented, reasonSearchUnavailable)
+
+	_, err = s.SearchRaces(context.Background(), &racing.SearchRacesRequest{Query: "--", PageSize: 10})
+	assertReason(t, err, codes.InvalidArgument, reasonInvalidQuery)
+
+	_, err = s.SearchRaces(context.Background(), &racing.SearchRacesRequest{Query: " "})
+	assertReason(t, err, codes.InvalidArgument, reasonInvalidQuery)
+
+	_, err = s.SearchRaces(context.Background(), &racing.SearchRacesRequest{Query: "cox", PageSize: -1})
+	assertReason(t, err, codes.InvalidArgument, reasonInvalidPageSize)
+
+	mockRepo.AssertNumberOfCalls(t, "Search", 3)
+}
+
+// assertProtoEqual compares messages with proto.Equal, as trimming them
+// leaves internal state assert.Equal would compare.
+func assertProtoEqual(t *testing.T, want, got proto.Message) {
+	t.Helper()
+	assert.True(t, proto.Equal(want, got), "want %v, got %v", want, got)
+}
+
+// Races are trimmed to the read mask, including fields the repository only
+// read to order them by, and embedded messages outside it aren't loaded.
+func TestListRaces_ReadMask(t *testing.T) {
+	mockRepo := new(MockRacesRepo)
+	s := NewRacingService(mockRepo, new(MockMeetingsRepo), new(MockRunnersRepo))
+
+	start := timestamppb.New(time.Date(2021, 3, 2, 5, 0, 0, 0, time.UTC))
+	mockRepo.On("List", mock.Anything, (*racing.ListRacesRequestFilter)(nil), db.ListOptions{
+		PageSize: storage.DefaultPageSize,
+		OrderBy:  "meeting_id",
+		Fields:   []string{"id", "name", "advertised_start_time"},
+	}).Return([]*racing.Race{{Id: 1, MeetingId: 4, Name: "Cox Plate", AdvertisedStartTime: start}}, "", nil)
+
+	resp, err := s.ListRaces(context.Background(), &racing.ListRacesRequest{
+		OrderBy:        "meeting_id",
+		IncludeMeeting: true,
+		ReadMask:       &fieldmaskpb.FieldMask{Paths: []string{"id", "name", "advertised_start_time", "name"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Races, 1)
+	assertProtoEqual(t, &racing.Race{Id: 1, Name: "Cox Plate", AdvertisedStartTime: start}, resp.Races[0])
+
+	for _, path := range []string{"venue", "meeting.venue", "advertisedStartTime", "*"} {
+		_, err = s.ListRaces(context.Background(), &racing.ListRacesRequest{ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"id", path}}})
+		assertReason(t, err, codes.InvalidArgument, reasonInvalidReadMask)
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "List", 1)
+}
+
+func TestGetRaceByID_ReadMask(t *testing.T) {
+	racesRepo := new(MockRacesRepo)
+	runnersRepo := new(MockRunnersRepo)
+	s := NewRacingService(racesRepo, new(MockMeetingsRepo), runnersRepo)
+
+	racesRepo.On("GetByIDWithFields", mock.Anything, int64(1), []string{"name"}).Return(&racing.Race{Id: 1, Name: "Cox Plate"}, nil)
+	racesRepo.On("GetByIDWithFields", mock.Anything, int64(1), []string{"id", "runners"}).Return(&racing.Race{Id: 1}, nil)
+	runnersRepo.On("List", mock.Anything, int64(1)).Return([]*racing.Runner{{Number: 1}}, nil)
+
+	resp, err := s.GetRaceByID(context.Background(), &racing.GetRaceByIDRequest{
+		RaceId:         1,
+		IncludeRunners: true,
+		ReadMask:       &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+	require.NoError(t, err)
+	assertProtoEqual(t, &racing.Race{Name: "Cox Plate"}, resp.Race)
+	runnersRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+
+	resp, err = s.GetRaceByID(context.Background(), &racing.GetRaceByIDRequest{
+		RaceId:         1,
+		IncludeRunners: true,
+		ReadMask:       &fieldmaskpb.FieldMask{Paths: []string{"id", "runners"}},
+	})
+	require.NoError(t, err)
+	assertProtoEqual(t, &racing.Race{Id: 1, Runners: []*racing.Runner{{Number: 1}}}, resp.Race)
+
+	// The deprecated status is read alongside race_status, but is only
+	// returned if the mask names it too.
+	racesRepo.On("GetByIDWithFields", mock.Anything, int64(1), []string{"race_status"}).
+		Return(&racing.Race{Id: 1, Status: "CLOSED", RaceStatus: racing.RaceStatus_CLOSED}, nil)
+	resp, err = s.GetRaceByID(context.Background(), &racing.GetRaceByIDRequest{
+		RaceId:   1,
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"race_status"}},
+	})
+	require.NoError(t, err)
+	assertProtoEqual(t, &racing.Race{RaceStatus: racing.RaceStatus_CLOSED}, resp.Race)
+
+	_, err = s.GetRaceByID(context.Background(), &racing.GetRaceByIDRequest{RaceId: 1, ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"silks_url"}}})
+	assertReason(t, err, codes.InvalidArgument, reasonInvalidReadMask)
+}
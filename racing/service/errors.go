@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Kim-Hardie/entain-master/racing/db"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain identifies the racing service in ErrorInfo details.
+const errorDomain = "racing.Racing"
+
+// Reasons set on ErrorInfo details, so clients can tell errors sharing a code
+// apart without parsing messages.
+const (
+	reasonRaceNotFound           = "RACE_NOT_FOUND"
+	reasonMeetingNotFound        = "MEETING_NOT_FOUND"
+	reasonInvalidPageSize        = "INVALID_PAGE_SIZE"
+	reasonInvalidPageToken       = "INVALID_PAGE_TOKEN"
+	reasonInvalidOrderBy         = "INVALID_ORDER_BY"
+	reasonInvalidFilter          = "INVALID_FILTER"
+	reasonInvalidRaceStatus      = "INVALID_RACE_STATUS"
+	reasonInvalidStartTimeWindow = "INVALID_START_TIME_WINDOW"
+	reasonInvalidRaceType        = "INVALID_RACE_TYPE"
+	reasonInvalidDateWindow      = "INVALID_DATE_WINDOW"
+	reasonIllegalTransition      = "ILLEGAL_STATUS_TRANSITION"
+	reasonResultNotFound         = "RESULT_NOT_FOUND"
+	reasonInvalidPlacings        = "INVALID_PLACINGS"
+	reasonInvalidResumeToken     = "INVALID_RESUME_TOKEN"
+	reasonResumeTokenExpired     = "RESUME_TOKEN_EXPIRED"
+	reasonWatchLagged            = "WATCH_LAGGED"
+	reasonInvalidRace            = "INVALID_RACE"
+	reasonInvalidUpdateMask      = "INVALID_UPDATE_MASK"
+	reasonRaceAlreadyExists      = "RACE_ALREADY_EXISTS"
+	reasonRaceHasChildren        = "RACE_HAS_CHILDREN"
+	reasonActorRequired          = "ACTOR_REQUIRED"
+	reasonReasonRequired         = "REASON_REQUIRED"
+	reasonInvalidBatch           = "INVALID_BATCH"
+	reasonInvalidReadMask        = "INVALID_READ_MASK"
+	reasonInvalidQuery           = "INVALID_QUERY"
+	reasonSearchUnavailable      = "SEARCH_UNAVAILABLE"
+	reasonStorageUnavailable     = "STORAGE_UNAVAILABLE"
+)
+
+// statusError returns a gRPC error with the given code and message, carrying
+// an ErrorInfo detail with reason and metadata.
+func statusError(code codes.Code, reason string, metadata map[string]string, msg string) error {
+	st := status.New(code, msg)
+
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// repoError maps an error from the races, meetings or runners repository onto a gRPC
+// status. notFoundReason says what was missing if the error is db.ErrNotFound,
+// and metadata describes the request, e.g. the race asked for.
+func repoError(err error, notFoundReason string, metadata map[string]string) error {
+	switch {
+	case errors.Is(err, db.ErrNoResult):
+		return statusError(codes.NotFound, reasonResultNotFound, metadata, err.Error())
+	case errors.Is(err, db.ErrNotFound):
+		return statusError(codes.NotFound, notFoundReason, metadata, err.Error())
+	case errors.Is(err, db.ErrInvalidPageToken):
+		return statusError(codes.InvalidArgument, reasonInvalidPageToken, metadata, err.Error())
+	case errors.Is(err, db.ErrInvalidOrderBy):
+		return statusError(codes.InvalidArgument, reasonInvalidOrderBy, metadata, err.Error())
+	case errors.Is(err, db.ErrInvalidQuery):
+		return statusError(codes.InvalidArgument, reasonInvalidQuery, metadata, err.Error())
+	case errors.Is(err, db.ErrInvalidFilter):
+		return statusError(codes.InvalidArgument, reasonInvalidFilter, metadata, err.Error())
+	case errors.Is(err, db.ErrInvalidResult):
+		return statusError(codes.InvalidArgument, reasonInvalidPlacings, metadata, err.Error())
+	case errors.Is(err, db.ErrInvalidRace):
+		return statusError(codes.InvalidArgument, reasonInvalidRace, metadata, err.Error())
+	case errors.Is(err, db.ErrAlreadyExists):
+		return statusError(codes.AlreadyExists, reasonRaceAlreadyExists, metadata, err.Error())
+	case errors.Is(err, db.ErrRaceHasChildren):
+		return statusError(codes.FailedPrecondition, reasonRaceHasChildren, metadata, err.Error())
+	case errors.Is(err, db.ErrIllegalTransition):
+		return statusError(codes.FailedPrecondition, reasonIllegalTransition, metadata, err.Error())
+	case errors.Is(err, db.ErrSearchUnavailable):
+		return statusError(codes.Unimplemented, reasonSearchUnavailable, metadata, err.Error())
+	case errors.Is(err, db.ErrUnavailable):
+		return statusError(codes.Unavailable, reasonStorageUnavailable, metadata, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/Kim-Hardie/entain-master/proto/racing"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// feedRetention is how many recent changes are kept for watches to
+	// resume from.
+	feedRetention = 1024
+	// feedBufferSize is how many changes a watch may fall behind by before
+	// it is dropped, so a slow client can't hold up the rest.
+	feedBufferSize = 256
+)
+
+var (
+	// errInvalidResumeToken is returned for a resume token the feed didn't issue.
+	errInvalidResumeToken = errors.New("invalid resume token")
+	// errResumeTokenExpired is returned for a resume token whose changes are
+	// no longer kept, including tokens issued before the service restarted.
+	errResumeTokenExpired = errors.New("resume token expired")
+)
+
+// changeFeed fans race changes made through the service out to watches.
+// Each change is numbered, and the most recent are kept so a watch can resume
+// after the last change it saw.
+type changeFeed struct {
+	mu         sync.Mutex
+	epoch      int64
+	seq        uint64
+	recent     []*change
+	retention  int
+	bufferSize int
+	subs       map[*subscription]struct{}
+	now        func() time.Time
+}
+
+// change is a published change to a race.
+type change struct {
+	// event is sent to watches matching the race after the change.
+	event *pb.RaceEvent
+	// removed is sent instead to watches that matched the race before the
+	// change but no longer do. It is nil if there was no race before.
+	removed *pb.RaceEvent
+	// before is the race before the change, or nil for a new race.
+	before *pb.Race
+}
+
+// eventFor returns the event a watch matching match is sent for c, or nil if
+// the race neither matches now nor did before.
+func (c *change) eventFor(match func(*pb.Race) bool) *pb.RaceEvent {
+	switch {
+	case match(c.event.Race):
+		return c.event
+	case c.before != nil && match(c.before):
+		return c.removed
+	}
+	return nil
+}
+
+// subscription receives the changes matching a watch's filter. events is
+// closed if the watch falls more than the feed's buffer size behind.
+type subscription struct {
+	events chan *pb.RaceEvent
+	match  func(*pb.Race) bool
+}
+
+// newChangeFeed returns a feed keeping retention recent changes and letting
+// each watch fall bufferSize changes behind.
+func newChangeFeed(retention, bufferSize int, now func() time.Time) *changeFeed {
+	return &changeFeed{
+		epoch:      now().UnixNano(),
+		retention:  retention,
+		bufferSize: bufferSize,
+		subs:       make(map[*subscription]struct{}),
+		now:        now,
+	}
+}
+
+// publish records a change from before to race and sends it to every watch
+// matching either, dropping watches that have fallen too far behind. Watches
+// only matching before are sent a REMOVED event, as the race has left their
+// filter. before is nil for a new race, and race is the race as it was for a
+// deleted one. It never blocks.
+func (f *changeFeed) publish(eventType pb.RaceEventType, before, race *pb.Race) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	c := &change{
+		event: &pb.RaceEvent{
+			Type:        eventType,
+			Race:        race,
+			ResumeToken: f.token(f.seq),
+			OccurredAt:  timestamppb.New(f.now()),
+		},
+		before: before,
+	}
+	if before != nil {
+		c.removed = &pb.RaceEvent{
+			Type:        pb.RaceEventType_REMOVED,
+			Race:        race,
+			ResumeToken: c.event.ResumeToken,
+			OccurredAt:  c.event.OccurredAt,
+		}
+	}
+
+	f.recent = append(f.recent, c)
+	if len(f.recent) > f.retention {
+		f.recent = f.recent[len(f.recent)-f.retention:]
+	}
+
+	for sub := range f.subs {
+		event := c.eventFor(sub.match)
+		if event == nil {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			f.drop(sub)
+		}
+	}
+}
+
+// subscribe starts a subscription to the changes matching match. With an
+// empty resumeToken it starts from now, returning a token for the current
+// position to hand out once the caller has sent its snapshot. Otherwise the
+// changes since resumeToken are queued on the subscription first.
+func (f *changeFeed) subscribe(match func(*pb.Race) bool, resumeToken string) (*subscription, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var replay []*pb.RaceEvent
+	if resumeToken != "" {
+		after, err := f.parseToken(resumeToken)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Changes after the token must still be kept, i.e. the change it
+		// names must be at most one before the oldest kept.
+		oldest := f.seq - uint64(len(f.recent)) + 1
+		if after+1 < oldest {
+			return nil, "", errResumeTokenExpired
+		}
+
+		for _, c := range f.recent[len(f.recent)-int(f.seq-after):] {
+			if event := c.eventFor(match); event != nil {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	sub := &subscription{
+		events: make(chan *pb.RaceEvent, len(replay)+f.bufferSize),
+		match:  match,
+	}
+	for _, event := range replay {
+		sub.events <- event
+	}
+	f.subs[sub] = struct{}{}
+
+	return sub, f.token(f.seq), nil
+}
+
+// unsubscribe ends a subscription. It is safe to call more than once.
+func (f *changeFeed) unsubscribe(sub *subscription) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.drop(sub)
+}
+
+// drop closes sub if it is still subscribed. f.mu must be held.
+func (f *changeFeed) drop(sub *subscription) {
+	if _, ok := f.subs[sub]; ok {
+		delete(f.subs, sub)
+		close(sub.events)
+	}
+}
+
+// token returns the resume token for the change numbered seq.
+func (f *changeFeed) token(seq uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", f.epoch, seq)))
+}
+
+// parseToken returns the change number a resume token names. Tokens from a
+// previous run of the service have a different epoch and have expired.
+func (f *changeFeed) parseToken(token string) (uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", errInvalidResumeToken, err)
+	}
+
+	var (
+		epoch int64
+		seq   uint64
+	)
+	if _, err := fmt.Sscanf(string(raw), "%d:%d", &epoch, &seq); err != nil {
+		return 0, fmt.Errorf("%w: %s", errInvalidResumeToken, err)
+	}
+
+	if epoch != f.epoch {
+		return 0, errResumeTokenExpired
+	}
+	if seq > f.seq {
+		return 0, fmt.Errorf("%w: change %d hasn't happened", errInvalidResumeToken, seq)
+	}
+
+	return seq, nil
+}
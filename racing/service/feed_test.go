@@ -0,0 +1,164 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/proto/racing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func matchAll(*racing.Race) bool { return true }
+
+// receive returns the events queued on sub without waiting for more.
+func receive(sub *subscription) []*racing.RaceEvent {
+	var events []*racing.RaceEvent
+	for {
+		select {
+		case event, ok := <-sub.events:
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestChangeFeed_PublishMatching(t *testing.T) {
+	feed := newChangeFeed(10, 10, time.Now)
+
+	odd, _, err := feed.subscribe(func(race *racing.Race) bool { return race.Id%2 == 1 }, "")
+	require.NoError(t, err)
+	all, _, err := feed.subscribe(matchAll, "")
+	require.NoError(t, err)
+
+	for id := int64(1); id <= 3; id++ {
+		feed.publish(racing.RaceEventType_STATUS_CHANGED, nil, &racing.Race{Id: id})
+	}
+
+	var ids []int64
+	for _, event := range receive(odd) {
+		ids = append(ids, event.Race.Id)
+	}
+	assert.Equal(t, []int64{1, 3}, ids)
+	assert.Len(t, receive(all), 3)
+
+	feed.unsubscribe(odd)
+	feed.unsubscribe(odd)
+	_, open := <-odd.events
+	assert.False(t, open)
+}
+
+// Resuming replays the changes after the token, until they are no longer kept.
+func TestChangeFeed_Resume(t *testing.T) {
+	feed := newChangeFeed(3, 10, time.Now)
+
+	sub, start, err := feed.subscribe(matchAll, "")
+	require.NoError(t, err)
+
+	for id := int64(1); id <= 3; id++ {
+		feed.publish(racing.RaceEventType_STATUS_CHANGED, nil, &racing.Race{Id: id})
+	}
+	events := receive(sub)
+	require.Len(t, events, 3)
+
+	resumed, _, err := feed.subscribe(matchAll, start)
+	require.NoError(t, err)
+	assert.Equal(t, events, receive(resumed))
+
+	resumed, token, err := feed.subscribe(matchAll, events[1].ResumeToken)
+	require.NoError(t, err)
+	assert.Equal(t, events[2:], receive(resumed))
+	assert.Equal(t, events[2].ResumeToken, token)
+
+	// The first change is no longer kept once a fourth is made.
+	feed.publish(racing.RaceEventType_STATUS_CHANGED, nil, &racing.Race{Id: 4})
+	_, _, err = feed.subscribe(matchAll, start)
+	assert.ErrorIs(t, err, errResumeTokenExpired)
+
+	_, _, err = feed.subscribe(matchAll, events[0].ResumeToken)
+	assert.NoError(t, err)
+}
+
+func TestChangeFeed_BadTokens(t *testing.T) {
+	feed := newChangeFeed(10, 10, time.Now)
+	_, token, err := feed.subscribe(matchAll, "")
+	require.NoError(t, err)
+
+	// A token from before a restart.
+	restarted := newChangeFeed(10, 10, func() time.Time { return time.Now().Add(time.Second) })
+	_, _, err = restarted.subscribe(matchAll, token)
+	assert.ErrorIs(t, err, errResumeTokenExpired)
+
+	for _, token := range []string{"not a token!", "Zm9v", feed.token(5)} {
+		_, _, err := feed.subscribe(matchAll, token)
+		assert.ErrorIs(t, err, errInvalidResumeToken, token)
+	}
+}
+
+// A subscription that falls a full buffer behind is dropped rather than
+// blocking the feed.
+func TestChangeFeed_DropsLaggingSubscription(t *testing.T) {
+	feed := newChangeFeed(10, 2, time.Now)
+
+	slow, _, err := feed.subscribe(matchAll, "")
+	require.NoError(t, err)
+	fast, _, err := feed.subscribe(matchAll, "")
+	require.NoError(t, err)
+
+	for id := int64(1); id <= 3; id++ {
+		feed.publish(racing.RaceEventType_STATUS_CHANGED, nil, &racing.Race{Id: id})
+		receive(fast)
+	}
+
+	assert.Len(t, receive(slow), 2)
+	_, open := <-slow.events
+	assert.False(t, open)
+
+	feed.publish(racing.RaceEventType_STATUS_CHANGED, nil, &racing.Race{Id: 4})
+	assert.Len(t, receive(fast), 1)
+}
+
+// A race leaving a subscription's filter is sent as REMOVED, live and when
+// resuming, and races that never matched aren't sent at all.
+func TestChangeFeed_PublishRemoved(t *testing.T) {
+	feed := newChangeFeed(10, 10, time.Now)
+	open := func(race *racing.Race) bool { return race.RaceStatus == racing.RaceStatus_OPEN }
+
+	sub, start, err := feed.subscribe(open, "")
+	require.NoError(t, err)
+
+	feed.publish(racing.RaceEventType_STATUS_CHANGED,
+		&racing.Race{Id: 1, RaceStatus: racing.RaceStatus_OPEN},
+		&racing.Race{Id: 1, RaceStatus: racing.RaceStatus_SUSPENDED})
+	feed.publish(racing.RaceEventType_STATUS_CHANGED,
+		&racing.Race{Id: 2, RaceStatus: racing.RaceStatus_SUSPENDED},
+		&racing.Race{Id: 2, RaceStatus: racing.RaceStatus_ABANDONED})
+	feed.publish(racing.RaceEventType_STATUS_CHANGED,
+		&racing.Race{Id: 1, RaceStatus: racing.RaceStatus_SUSPENDED},
+		&racing.Race{Id: 1, RaceStatus: racing.RaceStatus_OPEN})
+
+	events := receive(sub)
+	require.Len(t, events, 2)
+	assert.Equal(t, racing.RaceEventType_REMOVED, events[0].Type)
+	assert.Equal(t, racing.RaceStatus_SUSPENDED, events[0].Race.RaceStatus)
+	assert.Equal(t, racing.RaceEventType_STATUS_CHANGED, events[1].Type)
+	assert.Equal(t, racing.RaceStatus_OPEN, events[1].Race.RaceStatus)
+
+	// The skipped change still moves the token on.
+	assert.NotEqual(t, events[0].ResumeToken, events[1].ResumeToken)
+
+	resumed, _, err := feed.subscribe(open, start)
+	require.NoError(t, err)
+	assert.Equal(t, events, receive(resumed))
+
+	// Watchers of every race see the change itself.
+	all, _, err := feed.subscribe(matchAll, start)
+	require.NoError(t, err)
+	for _, event := range receive(all) {
+		assert.Equal(t, racing.RaceEventType_STATUS_CHANGED, event.Type)
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	pb "github.com/Kim-Hardie/entain-master/proto/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// dateLayout is the YYYY-MM-DD format of meeting dates.
+const dateLayout = "2006-01-02"
+
+// validateRaceFilter checks a ListRaces or WatchRaces filter only names known
+// statuses and categories and has a valid start time window.
+func validateRaceFilter(filter *pb.ListRacesRequestFilter) error {
+	for _, raceStatus := range filter.GetStatuses() {
+		if !validRaceStatus(raceStatus) {
+			return statusError(codes.InvalidArgument, reasonInvalidRaceStatus, map[string]string{"status": strconv.Itoa(int(raceStatus))},
+				fmt.Sprintf("unknown race status %d in filter", raceStatus))
+		}
+	}
+
+	for _, category := range filter.GetCategories() {
+		if !validRaceType(category) {
+			return statusError(codes.InvalidArgument, reasonInvalidRaceType, map[string]string{"category": strconv.Itoa(int(category))},
+				fmt.Sprintf("unknown category %d in filter", category))
+		}
+	}
+
+	return validateStartTimeWindow(filter.GetStartTimeFrom(), filter.GetStartTimeTo())
+}
+
+// matchRaceFilter reports whether race matches filter the way the races
+// repository's List would, so watches see the same races as ListRaces. It
+// can't tell whether a race has a result, so has_result is ignored.
+func matchRaceFilter(filter *pb.ListRacesRequestFilter, race *pb.Race) bool {
+	if filter == nil {
+		return true
+	}
+
+	if len(filter.MeetingIds) > 0 && !containsInt64(filter.MeetingIds, race.MeetingId) {
+		return false
+	}
+
+	// As with List, a filter without showOnlyVisible only shows visible races.
+	visible := true
+	if filter.ShowOnlyVisible != nil {
+		visible = *filter.ShowOnlyVisible
+	}
+	if race.Visible != visible {
+		return false
+	}
+
+	if len(filter.Statuses) > 0 && !containsRaceStatus(filter.Statuses, race.RaceStatus) {
+		return false
+	}
+
+	if len(filter.Categories) > 0 && !containsRaceType(filter.Categories, race.Category) {
+		return false
+	}
+
+	start := race.GetAdvertisedStartTime().AsTime()
+	if filter.StartTimeFrom != nil && start.Before(filter.StartTimeFrom.AsTime()) {
+		return false
+	}
+	if filter.StartTimeTo != nil && !start.Before(filter.StartTimeTo.AsTime()) {
+		return false
+	}
+
+	return true
+}
+
+func containsInt64(values []int64, value int64) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRaceStatus(values []pb.RaceStatus, value pb.RaceStatus) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRaceType(values []pb.RaceType, value pb.RaceType) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateStartTimeWindow checks the start_time_from/start_time_to filters
+// are valid timestamps describing a non-empty window.
+func validateStartTimeWindow(from, to *timestamppb.Timestamp) error {
+	if from != nil {
+		if err := from.CheckValid(); err != nil {
+			return statusError(codes.InvalidArgument, reasonInvalidStartTimeWindow, nil, fmt.Sprintf("invalid start_time_from: %s", err))
+		}
+	}
+
+	if to != nil {
+		if err := to.CheckValid(); err != nil {
+			return statusError(codes.InvalidArgument, reasonInvalidStartTimeWindow, nil, fmt.Sprintf("invalid start_time_to: %s", err))
+		}
+	}
+
+	if from != nil && to != nil && !from.AsTime().Before(to.AsTime()) {
+		return statusError(codes.InvalidArgument, reasonInvalidStartTimeWindow, nil, "start_time_from must be before start_time_to")
+	}
+
+	return nil
+}
+
+// validateDateWindow checks the date_from/date_to filters are YYYY-MM-DD
+// dates describing a non-empty window. Either may be empty.
+func validateDateWindow(from, to string) error {
+	fromDate, err := parseDateFilter("date_from", from)
+	if err != nil {
+		return err
+	}
+
+	toDate, err := parseDateFilter("date_to", to)
+	if err != nil {
+		return err
+	}
+
+	if from != "" && to != "" && !fromDate.Before(toDate) {
+		return statusError(codes.InvalidArgument, reasonInvalidDateWindow, nil, "date_from must be before date_to")
+	}
+
+	return nil
+}
+
+// parseDateFilter parses the YYYY-MM-DD date filter called name, if set.
+func parseDateFilter(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, statusError(codes.InvalidArgument, reasonInvalidDateWindow, nil, fmt.Sprintf("invalid %s %q, want YYYY-MM-DD", name, value))
+	}
+
+	return date, nil
+}
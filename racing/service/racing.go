@@ -2,38 +2,690 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	pb "github.com/Kim-Hardie/entain-master/proto/racing"
 	"github.com/Kim-Hardie/entain-master/racing/db"
-	pb "github.com/Kim-Hardie/entain-master/racing/proto/racing"
+	"github.com/Kim-Hardie/entain-master/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxBatchSize caps the races a batch request may name.
+	maxBatchSize = 100
+	// actorMetadataKey is the metadata naming who makes an audited change.
+	actorMetadataKey = "x-actor"
 )
 
 // RacingService implements the RacingServer interface.
 type RacingService struct {
 	pb.UnimplementedRacingServer // embed this
 	racesRepo                    db.RacesRepo
+	meetingsRepo                 db.MeetingsRepo
+	runnersRepo                  db.RunnersRepo
+	feed                         *changeFeed
+	now                          func() time.Time
+	// writes is held from a write to the races until its change is
+	// published, so watches are sent changes in the order they were
+	// committed.
+	writes sync.Mutex
 }
 
 // NewRacingService instantiates and returns a new RacingService.
-func NewRacingService(racesRepo db.RacesRepo) *RacingService {
-	return &RacingService{racesRepo: racesRepo}
+func NewRacingService(racesRepo db.RacesRepo, meetingsRepo db.MeetingsRepo, runnersRepo db.RunnersRepo) *RacingService {
+	return &RacingService{
+		racesRepo:    racesRepo,
+		meetingsRepo: meetingsRepo,
+		runnersRepo:  runnersRepo,
+		feed:         newChangeFeed(feedRetention, feedBufferSize, time.Now),
+		now:          time.Now,
+	}
 }
 
 func (s *RacingService) ListRaces(ctx context.Context, in *pb.ListRacesRequest) (*pb.ListRacesResponse, error) {
-	races, err := s.racesRepo.List(in.Filter)
+	pageSize, err := normalisePageSize(in.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateRaceFilter(in.Filter); err != nil {
+		return nil, err
+	}
+
+	fields, err := readFields(in.ReadMask)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.ListRacesResponse{Races: races}, nil
+	races, nextPageToken, err := s.racesRepo.List(ctx, in.Filter, db.ListOptions{
+		PageSize:       pageSize,
+		PageToken:      in.PageToken,
+		OrderBy:        in.OrderBy,
+		IncludeMeeting: in.IncludeMeeting && reads(fields, "meeting"),
+		Fields:         fields,
+	})
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, nil)
+	}
+
+	for _, race := range races {
+		trimRace(race, fields)
+	}
+
+	return &pb.ListRacesResponse{Races: races, NextPageToken: nextPageToken}, nil
 }
 
 func (s *RacingService) GetRaceByID(ctx context.Context, req *pb.GetRaceByIDRequest) (*pb.GetRaceByIDResponse, error) {
-	race, err := s.racesRepo.GetByID(req.RaceId)
+	fields, err := readFields(req.ReadMask)
 	if err != nil {
 		return nil, err
 	}
 
+	// A missing race surfaces as NotFound, which the gateway maps to a 404,
+	// rather than an empty response.
+	race, err := s.racesRepo.GetByIDWithFields(ctx, req.RaceId, fields)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, raceMetadata(req.RaceId))
+	}
+	trimRace(race, fields)
+
+	if req.IncludeRunners && reads(fields, "runners") {
+		if race.Runners, err = s.runnersRepo.List(ctx, req.RaceId); err != nil {
+			return nil, repoError(err, reasonRaceNotFound, raceMetadata(req.RaceId))
+		}
+	}
+
 	return &pb.GetRaceByIDResponse{
 		Race: race,
 	}, nil
 }
+
+func (s *RacingService) BatchGetRaces(ctx context.Context, req *pb.BatchGetRacesRequest) (*pb.BatchGetRacesResponse, error) {
+	ids, err := batchIDs(req.RaceIds)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := s.racesRepo.BatchGet(ctx, ids)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, nil)
+	}
+
+	resp := &pb.BatchGetRacesResponse{}
+	for i, race := range found {
+		if race == nil {
+			resp.MissingRaceIds = append(resp.MissingRaceIds, ids[i])
+			continue
+		}
+		resp.Races = append(resp.Races, race)
+	}
+
+	return resp, nil
+}
+
+func (s *RacingService) SearchRaces(ctx context.Context, req *pb.SearchRacesRequest) (*pb.SearchRacesResponse, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, statusError(codes.InvalidArgument, reasonInvalidQuery, nil, "query is required")
+	}
+
+	pageSize, err := normalisePageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.racesRepo.Search(ctx, req.Query, pageSize)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, nil)
+	}
+
+	return &pb.SearchRacesResponse{Results: results}, nil
+}
+
+func (s *RacingService) CreateRace(ctx context.Context, req *pb.CreateRaceRequest) (*pb.CreateRaceResponse, error) {
+	if err := validateRace(req.Race, db.RaceUpdateFields); err != nil {
+		return nil, err
+	}
+
+	s.writes.Lock()
+	defer s.writes.Unlock()
+
+	race, err := s.racesRepo.Create(ctx, req.Race)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, nil)
+	}
+
+	s.feed.publish(pb.RaceEventType_CREATED, nil, race)
+
+	return &pb.CreateRaceResponse{Race: race}, nil
+}
+
+func (s *RacingService) UpdateRace(ctx context.Context, req *pb.UpdateRaceRequest) (*pb.UpdateRaceResponse, error) {
+	fields, err := updateFields(req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateRace(req.Race, fields); err != nil {
+		return nil, err
+	}
+
+	s.writes.Lock()
+	defer s.writes.Unlock()
+
+	// The race as it was is published too, so watches the change moves the
+	// race out of are told.
+	before, race, err := s.racesRepo.Update(ctx, req.Race, fields)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, raceMetadata(req.Race.Id))
+	}
+
+	s.feed.publish(pb.RaceEventType_UPDATED, before, race)
+
+	return &pb.UpdateRaceResponse{Race: race}, nil
+}
+
+func (s *RacingService) DeleteRace(ctx context.Context, req *pb.DeleteRaceRequest) (*pb.DeleteRaceResponse, error) {
+	s.writes.Lock()
+	defer s.writes.Unlock()
+
+	race, err := s.racesRepo.Delete(ctx, req.RaceId, req.Force)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, raceMetadata(req.RaceId))
+	}
+
+	s.feed.publish(pb.RaceEventType_DELETED, race, race)
+
+	return &pb.DeleteRaceResponse{}, nil
+}
+
+func (s *RacingService) ListRunners(ctx context.Context, req *pb.ListRunnersRequest) (*pb.ListRunnersResponse, error) {
+	runners, err := s.runnersRepo.List(ctx, req.RaceId)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, raceMetadata(req.RaceId))
+	}
+
+	return &pb.ListRunnersResponse{Runners: runners}, nil
+}
+
+func (s *RacingService) TransitionRaceStatus(ctx context.Context, req *pb.TransitionRaceStatusRequest) (*pb.TransitionRaceStatusResponse, error) {
+	if !validRaceStatus(req.Status) {
+		return nil, statusError(codes.InvalidArgument, reasonInvalidRaceStatus, map[string]string{"status": strconv.Itoa(int(req.Status))},
+			fmt.Sprintf("unknown race status %d", req.Status))
+	}
+
+	s.writes.Lock()
+	defer s.writes.Unlock()
+
+	before, race, err := s.racesRepo.TransitionStatus(ctx, req.RaceId, req.Status, req.Reason)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, raceMetadata(req.RaceId))
+	}
+
+	s.feed.publish(pb.RaceEventType_STATUS_CHANGED, before, race)
+
+	return &pb.TransitionRaceStatusResponse{Race: race}, nil
+}
+
+func (s *RacingService) SubmitResult(ctx context.Context, req *pb.SubmitResultRequest) (*pb.SubmitResultResponse, error) {
+	if err := validatePlacings(req.Placings); err != nil {
+		return nil, statusError(codes.InvalidArgument, reasonInvalidPlacings, raceMetadata(req.RaceId), err.Error())
+	}
+
+	s.writes.Lock()
+	defer s.writes.Unlock()
+
+	before, race, result, err := s.racesRepo.SubmitResult(ctx, req.RaceId, req.Placings, req.ProtestPending)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, raceMetadata(req.RaceId))
+	}
+
+	// Amending an interim result leaves the race INTERIM, but is still
+	// reported so watchers know to fetch the new placings.
+	s.feed.publish(pb.RaceEventType_STATUS_CHANGED, before, race)
+
+	return &pb.SubmitResultResponse{Race: race, Result: result}, nil
+}
+
+func (s *RacingService) GetRaceResult(ctx context.Context, req *pb.GetRaceResultRequest) (*pb.GetRaceResultResponse, error) {
+	result, err := s.racesRepo.GetResult(ctx, req.RaceId)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, raceMetadata(req.RaceId))
+	}
+
+	return &pb.GetRaceResultResponse{Result: result}, nil
+}
+
+func (s *RacingService) WatchRaces(req *pb.WatchRacesRequest, stream pb.Racing_WatchRacesServer) error {
+	if err := validateRaceFilter(req.Filter); err != nil {
+		return err
+	}
+
+	// Events carry races, not results, so there is nothing to match it against.
+	if req.Filter != nil && req.Filter.HasResult != nil {
+		return statusError(codes.InvalidArgument, reasonInvalidFilter, nil, "has_result isn't supported by WatchRaces")
+	}
+
+	sub, token, err := s.feed.subscribe(func(race *pb.Race) bool { return matchRaceFilter(req.Filter, race) }, req.ResumeToken)
+	switch {
+	case errors.Is(err, errInvalidResumeToken):
+		return statusError(codes.InvalidArgument, reasonInvalidResumeToken, nil, err.Error())
+	case errors.Is(err, errResumeTokenExpired):
+		return statusError(codes.OutOfRange, reasonResumeTokenExpired, nil, err.Error())
+	case err != nil:
+		return repoError(err, reasonRaceNotFound, nil)
+	}
+	defer s.feed.unsubscribe(sub)
+
+	ctx := stream.Context()
+
+	// Changes made while the snapshot is sent are queued on the subscription,
+	// so none are missed, though some may already show in the snapshot.
+	if req.ResumeToken == "" {
+		if err := s.sendSnapshot(ctx, stream, req.Filter, token); err != nil {
+			return err
+		}
+	} else {
+		token = req.ResumeToken
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event, ok := <-sub.events:
+			if !ok {
+				return statusError(codes.ResourceExhausted, reasonWatchLagged, map[string]string{"resume_token": token},
+					"watch fell too far behind, resume it from resume_token")
+			}
+
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			token = event.ResumeToken
+		}
+	}
+}
+
+// ReportJumps tells watches about races closing as their advertised start
+// times pass. No write closes them, as their status is derived when read, so
+// every interval it looks for races that have jumped since it last looked,
+// and publishes them as STATUS_CHANGED. It runs until ctx ends.
+func (s *RacingService) ReportJumps(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	since := s.now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			until := s.now()
+			if err := s.publishJumps(ctx, since, until); err != nil {
+				// The same window is looked at again next time.
+				log.Printf("failed reporting jumped races: %s", err)
+				continue
+			}
+			since = until
+		}
+	}
+}
+
+// publishJumps publishes the races advertised to start from from up to, but
+// not including, to that are still stored as OPEN, so only read as CLOSED
+// because they have jumped. Each is published from the row as stored, so
+// watches of only OPEN races are sent a REMOVED event. Races closed by hand
+// were published when they were closed, and are left out.
+func (s *RacingService) publishJumps(ctx context.Context, from, to time.Time) error {
+	s.writes.Lock()
+	defer s.writes.Unlock()
+
+	races, err := s.racesRepo.ListJumped(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, before := range races {
+		race := proto.Clone(before).(*pb.Race)
+		race.RaceStatus = pb.RaceStatus_CLOSED
+		race.Status = race.RaceStatus.String()
+		s.feed.publish(pb.RaceEventType_STATUS_CHANGED, before, race)
+	}
+
+	return nil
+}
+
+// sendSnapshot sends a SNAPSHOT event for every race matching filter, then a
+// SNAPSHOT_COMPLETE event carrying token.
+func (s *RacingService) sendSnapshot(ctx context.Context, stream pb.Racing_WatchRacesServer, filter *pb.ListRacesRequestFilter, token string) error {
+	now := timestamppb.New(s.now())
+	opts := db.ListOptions{PageSize: storage.MaxPageSize}
+
+	for {
+		races, nextPageToken, err := s.racesRepo.List(ctx, filter, opts)
+		if err != nil {
+			return repoError(err, reasonRaceNotFound, nil)
+		}
+
+		for _, race := range races {
+			if err := stream.Send(&pb.RaceEvent{Type: pb.RaceEventType_SNAPSHOT, Race: race, OccurredAt: now}); err != nil {
+				return err
+			}
+		}
+
+		if nextPageToken == "" {
+			break
+		}
+		opts.PageToken = nextPageToken
+	}
+
+	return stream.Send(&pb.RaceEvent{Type: pb.RaceEventType_SNAPSHOT_COMPLETE, ResumeToken: token, OccurredAt: now})
+}
+
+func (s *RacingService) SetRaceVisibility(ctx context.Context, req *pb.SetRaceVisibilityRequest) (*pb.SetRaceVisibilityResponse, error) {
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := batchIDs(req.RaceIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, statusError(codes.InvalidArgument, reasonReasonRequired, nil, "a reason is required to change visibility")
+	}
+
+	s.writes.Lock()
+	defer s.writes.Unlock()
+
+	before, races, err := s.racesRepo.SetVisibility(ctx, ids, req.Visible, actor, req.Reason)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, nil)
+	}
+
+	for i, race := range races {
+		s.feed.publish(pb.RaceEventType_UPDATED, before[i], race)
+	}
+
+	return &pb.SetRaceVisibilityResponse{Races: races}, nil
+}
+
+func (s *RacingService) ListRaceAudit(ctx context.Context, req *pb.ListRaceAuditRequest) (*pb.ListRaceAuditResponse, error) {
+	pageSize, err := normalisePageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, nextPageToken, err := s.racesRepo.ListAudit(ctx, req.Filter, pageSize, req.PageToken)
+	if err != nil {
+		return nil, repoError(err, reasonRaceNotFound, nil)
+	}
+
+	return &pb.ListRaceAuditResponse{Entries: entries, NextPageToken: nextPageToken}, nil
+}
+
+func (s *RacingService) ListMeetings(ctx context.Context, in *pb.ListMeetingsRequest) (*pb.ListMeetingsResponse, error) {
+	pageSize, err := normalisePageSize(in.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, raceType := range in.GetFilter().GetRaceTypes() {
+		if !validRaceType(raceType) {
+			return nil, statusError(codes.InvalidArgument, reasonInvalidRaceType, map[string]string{"race_type": strconv.Itoa(int(raceType))},
+				fmt.Sprintf("unknown race type %d in filter", raceType))
+		}
+	}
+
+	if err := validateDateWindow(in.GetFilter().GetDateFrom(), in.GetFilter().GetDateTo()); err != nil {
+		return nil, err
+	}
+
+	meetings, nextPageToken, err := s.meetingsRepo.List(ctx, in.Filter, pageSize, in.PageToken)
+	if err != nil {
+		return nil, repoError(err, reasonMeetingNotFound, nil)
+	}
+
+	return &pb.ListMeetingsResponse{Meetings: meetings, NextPageToken: nextPageToken}, nil
+}
+
+func (s *RacingService) GetMeeting(ctx context.Context, req *pb.GetMeetingRequest) (*pb.GetMeetingResponse, error) {
+	meeting, err := s.meetingsRepo.GetByID(ctx, req.MeetingId)
+	if err != nil {
+		return nil, repoError(err, reasonMeetingNotFound, map[string]string{"meeting_id": strconv.FormatInt(req.MeetingId, 10)})
+	}
+
+	return &pb.GetMeetingResponse{Meeting: meeting}, nil
+}
+
+// normalisePageSize defaults an unset page_size and caps a large one.
+func normalisePageSize(pageSize int32) (int32, error) {
+	pageSize, err := storage.PageSize(pageSize)
+	if err != nil {
+		return 0, statusError(codes.InvalidArgument, reasonInvalidPageSize, nil, err.Error())
+	}
+
+	return pageSize, nil
+}
+
+// actorFromContext returns who is making a call, from its x-actor metadata.
+// There's no authentication yet, so the caller is trusted to name itself.
+func actorFromContext(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, actor := range md.Get(actorMetadataKey) {
+		if actor = strings.TrimSpace(actor); actor != "" {
+			return actor, nil
+		}
+	}
+
+	return "", statusError(codes.Unauthenticated, reasonActorRequired, nil, fmt.Sprintf("the %s metadata must name the caller", actorMetadataKey))
+}
+
+// batchIDs checks the race ids of a batch request are positive and there are
+// at most maxBatchSize, counting duplicates, and returns them with duplicates
+// removed.
+func batchIDs(ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, statusError(codes.InvalidArgument, reasonInvalidBatch, nil, "race_ids is required")
+	}
+
+	// Checked before duplicates are removed, so the limit also bounds the
+	// work of removing them.
+	if len(ids) > maxBatchSize {
+		return nil, statusError(codes.InvalidArgument, reasonInvalidBatch, nil,
+			fmt.Sprintf("%d races asked for, at most %d are allowed", len(ids), maxBatchSize))
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, statusError(codes.InvalidArgument, reasonInvalidBatch, raceMetadata(id), fmt.Sprintf("race id %d must be positive", id))
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique, nil
+}
+
+// raceMetadata describes the race a request is for in ErrorInfo details.
+func raceMetadata(raceID int64) map[string]string {
+	return map[string]string{"race_id": strconv.FormatInt(raceID, 10)}
+}
+
+// validRaceType reports whether raceType is a known, specified race type.
+func validRaceType(raceType pb.RaceType) bool {
+	_, ok := pb.RaceType_name[int32(raceType)]
+	return ok && raceType != pb.RaceType_RACE_TYPE_UNSPECIFIED
+}
+
+// validRaceStatus reports whether raceStatus is a known, specified status.
+func validRaceStatus(raceStatus pb.RaceStatus) bool {
+	_, ok := pb.RaceStatus_name[int32(raceStatus)]
+	return ok && raceStatus != pb.RaceStatus_RACE_STATUS_UNSPECIFIED
+}
+
+// validatePlacings checks that placings name each runner once and rank them
+// from 1 using standard competition ranking, so runners that dead heat share a
+// position and the positions they span are skipped, e.g. 1, 1, 3.
+func validatePlacings(placings []*pb.Placing) error {
+	if len(placings) == 0 {
+		return fmt.Errorf("a result must place at least one runner")
+	}
+
+	placed := make(map[int64]bool, len(placings))
+	positions := make([]int64, 0, len(placings))
+	for _, placing := range placings {
+		if placing.GetRunnerNumber() <= 0 {
+			return fmt.Errorf("runner number %d must be positive", placing.GetRunnerNumber())
+		}
+		if placed[placing.RunnerNumber] {
+			return fmt.Errorf("runner %d is placed more than once", placing.RunnerNumber)
+		}
+		placed[placing.RunnerNumber] = true
+		positions = append(positions, placing.Position)
+	}
+
+	sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+	for i, position := range positions {
+		// Each position either shares the one before it in a dead heat or
+		// counts every runner placed ahead of it.
+		if position != int64(i+1) && (i == 0 || position != positions[i-1]) {
+			return fmt.Errorf("position %d should be %d, as %d runners are placed ahead of it", position, i+1, i)
+		}
+	}
+
+	return nil
+}
+
+// updateFields returns the race fields an update_mask names. An unset mask,
+// or "*", names every field that can be updated.
+func updateFields(mask *fieldmaskpb.FieldMask) ([]string, error) {
+	paths := mask.GetPaths()
+	if len(paths) == 0 || len(paths) == 1 && paths[0] == "*" {
+		return db.RaceUpdateFields, nil
+	}
+
+	var fields []string
+	for _, path := range paths {
+		if !containsString(db.RaceUpdateFields, path) {
+			return nil, statusError(codes.InvalidArgument, reasonInvalidUpdateMask, map[string]string{"path": path},
+				fmt.Sprintf("%q can't be updated, want one of %v", path, db.RaceUpdateFields))
+		}
+		if !containsString(fields, path) {
+			fields = append(fields, path)
+		}
+	}
+
+	return fields, nil
+}
+
+// readFields returns the Race fields a read_mask names, or nil if it names
+// them all. Only top-level fields can be named.
+func readFields(mask *fieldmaskpb.FieldMask) ([]string, error) {
+	paths := mask.GetPaths()
+	if len(paths) == 0 || len(paths) == 1 && paths[0] == "*" {
+		return nil, nil
+	}
+
+	known := (&pb.Race{}).ProtoReflect().Descriptor().Fields()
+
+	var fields []string
+	for _, path := range paths {
+		if known.ByName(protoreflect.Name(path)) == nil {
+			return nil, statusError(codes.InvalidArgument, reasonInvalidReadMask, map[string]string{"path": path},
+				fmt.Sprintf("%q isn't a field of a race", path))
+		}
+		if !containsString(fields, path) {
+			fields = append(fields, path)
+		}
+	}
+
+	return fields, nil
+}
+
+// reads reports whether a read mask's fields include field.
+func reads(fields []string, field string) bool {
+	return fields == nil || containsString(fields, field)
+}
+
+// trimRace clears the fields of race a read mask's fields don't include, such
+// as those the repository read to order races by.
+func trimRace(race *pb.Race, fields []string) {
+	if fields == nil {
+		return
+	}
+
+	message := race.ProtoReflect()
+	descriptors := message.Descriptor().Fields()
+	for i := 0; i < descriptors.Len(); i++ {
+		if descriptor := descriptors.Get(i); !containsString(fields, string(descriptor.Name())) {
+			message.Clear(descriptor)
+		}
+	}
+}
+
+// validateRace checks the given fields of a race being created or updated
+// are set to usable values.
+func validateRace(race *pb.Race, fields []string) error {
+	invalid := func(msg string) error {
+		return statusError(codes.InvalidArgument, reasonInvalidRace, raceMetadata(race.GetId()), msg)
+	}
+
+	if race == nil {
+		return invalid("race is required")
+	}
+
+	for _, field := range fields {
+		switch field {
+		case "meeting_id":
+			if race.MeetingId <= 0 {
+				return invalid("meeting_id is required")
+			}
+		case "name":
+			if strings.TrimSpace(race.Name) == "" {
+				return invalid("name is required")
+			}
+		case "number":
+			if race.Number <= 0 {
+				return invalid("number must be positive")
+			}
+		case "advertised_start_time":
+			if race.AdvertisedStartTime == nil {
+				return invalid("advertised_start_time is required")
+			}
+			if err := race.AdvertisedStartTime.CheckValid(); err != nil {
+				return invalid(fmt.Sprintf("invalid advertised_start_time: %s", err))
+			}
+		}
+	}
+
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"time"
+
+	"syreclabs.com/go/faker"
+)
+
+// sportCompetitions maps each seeded sport to the competitions its events belong to.
+var sportCompetitions = map[string][]string{
+	"AFL":         {"AFL Premiership"},
+	"NRL":         {"NRL Premiership", "State of Origin"},
+	"Cricket":     {"Big Bash League", "Sheffield Shield"},
+	"Rugby Union": {"Super Rugby Pacific"},
+	"Soccer":      {"A-League Men", "A-League Women"},
+}
+
+// createTables creates the events and event_participants tables if they
+// don't exist yet.
+func (r *eventsRepo) createTables() error {
+	_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS events (id INTEGER PRIMARY KEY, name TEXT NOT NULL, advertised_start_time ` + r.dialect.TimestampType() + ` NOT NULL, sport TEXT NOT NULL, competition TEXT NOT NULL, venue TEXT NOT NULL)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`CREATE TABLE IF NOT EXISTS event_participants (id ` + r.dialect.SerialPrimaryKey() + `, event_id INTEGER NOT NULL REFERENCES events(id), position INTEGER NOT NULL, name TEXT NOT NULL, UNIQUE (event_id, position))`)
+
+	return err
+}
+
+// seed gives the database dummy events, each with two participants.
+func (r *eventsRepo) seed() error {
+	sportNames := make([]string, 0, len(sportCompetitions))
+	for sport := range sportCompetitions {
+		sportNames = append(sportNames, sport)
+	}
+
+	for i := 1; i <= 50; i++ {
+		sport := faker.RandomChoice(sportNames)
+		home, away := faker.Team().Name(), faker.Team().Name()
+
+		_, err := r.db.Exec(
+			r.dialect.Rebind(`INSERT INTO events(id, name, advertised_start_time, sport, competition, venue) VALUES (?,?,?,?,?,?) ON CONFLICT DO NOTHING`),
+			i,
+			home+" vs "+away,
+			faker.Time().Between(time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 7)).Format(time.RFC3339),
+			sport,
+			faker.RandomChoice(sportCompetitions[sport]),
+			faker.Address().City()+" Stadium",
+		)
+		if err != nil {
+			return err
+		}
+
+		for position, name := range []string{home, away} {
+			_, err = r.db.Exec(r.dialect.Rebind(`INSERT INTO event_participants(event_id, position, name) VALUES (?,?,?) ON CONFLICT DO NOTHING`), i, position, name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/Kim-Hardie/entain-master/storage"
+)
+
+var (
+	// ErrNotFound is returned when the requested event doesn't exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrUnavailable is returned when the database can't currently serve the
+	// request, e.g. it is locked, can't be opened or can't be reached.
+	// Retrying may succeed. It is storage.ErrUnavailable, which errors from
+	// storage.Error wrap.
+	ErrUnavailable = storage.ErrUnavailable
+
+	// ErrSearchUnavailable is returned when events can't be searched, as the
+	// database is PostgreSQL or SQLite built without FTS5.
+	ErrSearchUnavailable = errors.New("search unavailable")
+
+	// ErrInvalidQuery is returned when a search query has no words to search
+	// for.
+	ErrInvalidQuery = errors.New("invalid search query")
+)
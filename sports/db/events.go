@@ -0,0 +1,247 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/proto/sports"
+	"github.com/Kim-Hardie/entain-master/storage"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+	"github.com/golang/protobuf/ptypes"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// EventsRepo provides repository access to sports events.
+type EventsRepo interface {
+	// Init will initialise our events repository.
+	Init() error
+
+	// List will return a list of events ordered by advertised start time.
+	List(ctx context.Context, filter *sports.ListEventsRequestFilter) ([]*sports.Event, error)
+
+	// GetByID will return an event by its ID, or ErrNotFound if there is none.
+	GetByID(ctx context.Context, id int64) (*sports.Event, error)
+
+	// Search will return up to limit events whose name or participants
+	// contain every word of query, best matches first. It returns
+	// ErrSearchUnavailable if the database can't be searched.
+	Search(ctx context.Context, query string, limit int32) ([]*EventSearchResult, error)
+}
+
+// EventSearchResult is an event found by Search.
+type EventSearchResult struct {
+	Event *sports.Event
+	// Snippet is the name or participants that matched best, with the
+	// matched words marked.
+	Snippet string
+	// Score is higher for better matches.
+	Score float64
+}
+
+type eventsRepo struct {
+	db      *sql.DB
+	dialect dialect.Dialect
+	init    sync.Once
+	now     func() time.Time
+	// searchable is set by Init if events can be searched.
+	searchable bool
+}
+
+// NewEventsRepo creates a new events repository. The database may be SQLite
+// or PostgreSQL; queries are adapted to whichever driver it was opened with.
+func NewEventsRepo(db *sql.DB) EventsRepo {
+	return NewEventsRepoWithClock(db, time.Now)
+}
+
+// NewEventsRepoWithClock creates a new events repository that derives event
+// status against the time returned by now, letting tests freeze time.
+func NewEventsRepoWithClock(db *sql.DB, now func() time.Time) EventsRepo {
+	return &eventsRepo{db: db, dialect: dialect.Of(db), now: now}
+}
+
+// Init prepares the events repository dummy data.
+func (r *eventsRepo) Init() error {
+	var err error
+
+	r.init.Do(func() {
+		if err = r.createTables(); err != nil {
+			return
+		}
+
+		// The search index is set up before seeding, so its triggers index
+		// the seeded events.
+		if err = r.initSearch(); err != nil {
+			return
+		}
+
+		// For test/example purposes, we seed the DB with some dummy events.
+		err = r.seed()
+	})
+
+	return err
+}
+
+// List returns the events matching filter, with their participants.
+func (r *eventsRepo) List(ctx context.Context, filter *sports.ListEventsRequestFilter) ([]*sports.Event, error) {
+	query, args := r.applyFilter(getEventQueries(r.dialect)[eventsList], filter)
+	args = append([]interface{}{r.now()}, args...)
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	events, err := r.scanEvents(rows)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	if err := r.loadParticipants(ctx, events); err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	return events, nil
+}
+
+// GetByID returns an event by its ID, or ErrNotFound if there is none.
+func (r *eventsRepo) GetByID(ctx context.Context, eventID int64) (*sports.Event, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(getEventQueries(r.dialect)[eventsList]+" WHERE id = ?"), r.now(), eventID)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	events, err := r.scanEvents(rows)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("event %d: %w", eventID, ErrNotFound)
+	}
+
+	if err := r.loadParticipants(ctx, events); err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	return events[0], nil
+}
+
+// applyFilter applies the provided filter to the SQL query.
+func (r *eventsRepo) applyFilter(query string, filter *sports.ListEventsRequestFilter) (string, []interface{}) {
+	var (
+		clauses []string
+		args    []interface{}
+	)
+
+	if filter != nil {
+		if filter.Sport != "" {
+			clauses = append(clauses, "sport = ?")
+			args = append(args, filter.Sport)
+		}
+
+		if filter.Competition != "" {
+			clauses = append(clauses, "competition = ?")
+			args = append(args, filter.Competition)
+		}
+
+		if filter.StartTimeFrom != nil {
+			clauses = append(clauses, r.dialect.Timestamp("advertised_start_time")+" >= "+r.dialect.Timestamp("?"))
+			args = append(args, filter.StartTimeFrom.AsTime())
+		}
+
+		if filter.StartTimeTo != nil {
+			clauses = append(clauses, r.dialect.Timestamp("advertised_start_time")+" < "+r.dialect.Timestamp("?"))
+			args = append(args, filter.StartTimeTo.AsTime())
+		}
+	}
+
+	if len(clauses) != 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+	query += " ORDER BY " + r.dialect.Timestamp("advertised_start_time") + " ASC, id ASC"
+
+	return query, args
+}
+
+// scanEvents scans the database rows and converts them to events.
+func (r *eventsRepo) scanEvents(rows *sql.Rows) ([]*sports.Event, error) {
+	var events []*sports.Event
+
+	for rows.Next() {
+		var event sports.Event
+
+		if err := r.scanEvent(rows, &event); err != nil {
+			return nil, err
+		}
+
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+// scanEvent scans the current row into event, and any columns following the
+// event's into extra.
+func (r *eventsRepo) scanEvent(rows *sql.Rows, event *sports.Event, extra ...interface{}) error {
+	var advertisedStart time.Time
+	var status string
+
+	dest := append([]interface{}{&event.Id, &event.Name, &advertisedStart, &status, &event.Sport, &event.Competition, &event.Venue}, extra...)
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+
+	ts, err := ptypes.TimestampProto(advertisedStart)
+	if err != nil {
+		return err
+	}
+
+	event.AdvertisedStartTime = ts
+	event.Status = sports.EventStatus(sports.EventStatus_value[status])
+
+	return nil
+}
+
+// loadParticipants fills in the participants of events with a single query.
+func (r *eventsRepo) loadParticipants(ctx context.Context, events []*sports.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*sports.Event, len(events))
+	args := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		byID[event.Id] = event
+		args = append(args, event.Id)
+	}
+
+	query := getEventQueries(r.dialect)[eventParticipantsList] + " WHERE event_id IN (" + strings.Repeat("?,", len(events)-1) + "?) ORDER BY event_id, position"
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			eventID     int64
+			participant sports.Participant
+		)
+
+		if err := rows.Scan(&eventID, &participant.Id, &participant.Name); err != nil {
+			return err
+		}
+
+		event := byID[eventID]
+		event.Participants = append(event.Participants, &participant)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kim-Hardie/entain-master/proto/sports"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestDB opens an empty database for a test. Tests run against a SQLite
+// file unless TEST_DB_DRIVER is postgres, in which case TEST_DB_DSN names a
+// PostgreSQL database and each test gets a schema of its own in it.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	if os.Getenv("TEST_DB_DRIVER") != dialect.PostgresDriver {
+		sportsDB, err := sql.Open(dialect.SQLiteDriver, filepath.Join(t.TempDir(), "sports.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { sportsDB.Close() })
+
+		return sportsDB
+	}
+
+	dsn := os.Getenv("TEST_DB_DSN")
+	require.NotEmpty(t, dsn, "TEST_DB_DSN must be set to test against PostgreSQL")
+
+	adminDB, err := sql.Open(dialect.PostgresDriver, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { adminDB.Close() })
+
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	_, err = adminDB.Exec(`CREATE SCHEMA ` + schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { adminDB.Exec(`DROP SCHEMA ` + schema + ` CASCADE`) })
+
+	// Point every connection at the test's schema.
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		require.NoError(t, err)
+		query := u.Query()
+		query.Set("search_path", schema)
+		u.RawQuery = query.Encode()
+		dsn = u.String()
+	} else {
+		dsn += " search_path=" + schema
+	}
+
+	sportsDB, err := sql.Open(dialect.PostgresDriver, dsn)
+	require.NoError(t, err)
+	// Registered after the schema is dropped, so runs before it.
+	t.Cleanup(func() { sportsDB.Close() })
+
+	return sportsDB
+}
+
+// newTestEventsRepo returns an events repository backed by a freshly seeded database.
+func newTestEventsRepo(t *testing.T) *eventsRepo {
+	t.Helper()
+
+	sportsDB := openTestDB(t)
+
+	repo := NewEventsRepo(sportsDB).(*eventsRepo)
+	require.NoError(t, repo.Init())
+
+	return repo
+}
+
+func TestEventsRepo_List(t *testing.T) {
+	repo := newTestEventsRepo(t)
+
+	frozen := time.Now()
+	repo.now = func() time.Time { return frozen }
+
+	events, err := repo.List(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, events, 50)
+
+	for i, event := range events {
+		if i > 0 {
+			assert.False(t, event.AdvertisedStartTime.AsTime().Before(events[i-1].AdvertisedStartTime.AsTime()), "events are ordered by start time")
+		}
+
+		want := sports.EventStatus_CLOSED
+		if event.AdvertisedStartTime.AsTime().After(frozen) {
+			want = sports.EventStatus_OPEN
+		}
+		assert.Equal(t, want, event.Status)
+
+		require.Len(t, event.Participants, 2)
+		assert.Equal(t, event.Participants[0].Name+" vs "+event.Participants[1].Name, event.Name)
+		assert.NotEmpty(t, event.Competition)
+	}
+
+	sport := events[0].Sport
+	filtered, err := repo.List(context.Background(), &sports.ListEventsRequestFilter{Sport: sport})
+	require.NoError(t, err)
+	require.NotEmpty(t, filtered)
+	for _, event := range filtered {
+		assert.Equal(t, sport, event.Sport)
+	}
+}
+
+func TestEventsRepo_GetByID(t *testing.T) {
+	repo := newTestEventsRepo(t)
+
+	event, err := repo.GetByID(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), event.Id)
+	assert.Len(t, event.Participants, 2)
+
+	event, err = repo.GetByID(context.Background(), 1000)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, event)
+}
+
+// Cancelling the context aborts a slow query rather than waiting for it to finish.
+func TestEventsRepo_Cancelled(t *testing.T) {
+	repo := newTestEventsRepo(t)
+
+	// Swap the events table for a view that takes seconds to read.
+	_, err := repo.db.Exec(`ALTER TABLE events RENAME TO events_data`)
+	require.NoError(t, err)
+	_, err = repo.db.Exec(`CREATE VIEW events AS SELECT * FROM events_data WHERE (
+		WITH RECURSIVE counter(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM counter WHERE n < 100000000)
+		SELECT count(*) FROM counter
+	) > 0`)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = repo.List(ctx, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second), "query ran on after the deadline")
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+	"github.com/Kim-Hardie/entain-master/storage/fts"
+)
+
+const (
+	eventsList            = "list"
+	eventsSearch          = "search"
+	eventParticipantsList = "listParticipants"
+)
+
+// getEventQueries returns the SQL queries for sports events, with ?
+// placeholders to be rebound for d. Status is derived from the start time
+// against the current time bound to the first placeholder.
+func getEventQueries(d dialect.Dialect) map[string]string {
+	list := `
+			SELECT
+				id,
+				name,
+				advertised_start_time,
+				status,
+				sport,
+				competition,
+				venue
+			FROM (
+				SELECT
+					*,
+					CASE
+						WHEN ` + d.Timestamp("advertised_start_time") + ` > ` + d.Timestamp("?") + ` THEN 'OPEN'
+						ELSE 'CLOSED'
+					END AS status
+				FROM events
+			) AS events
+		`
+
+	return map[string]string{
+		eventsList: list,
+		// Search is only available on SQLite. The snippet is taken from
+		// whichever of name and participants matched best.
+		eventsSearch: `
+			SELECT e.id, e.name, e.advertised_start_time, e.status, e.sport, e.competition, e.venue,
+				` + fts.Snippet("events_fts", -1) + `, bm25(events_fts)
+			FROM events_fts JOIN (` + list + `) AS e ON e.id = events_fts.rowid
+			WHERE events_fts MATCH ?
+			ORDER BY bm25(events_fts), e.id
+			LIMIT ?`,
+		eventParticipantsList: `
+			SELECT
+				event_id,
+				id,
+				name
+			FROM event_participants
+		`,
+	}
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kim-Hardie/entain-master/proto/sports"
+	"github.com/Kim-Hardie/entain-master/storage"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+	"github.com/Kim-Hardie/entain-master/storage/fts"
+)
+
+// ftsRows selects the events_fts row of each event: its name and its
+// participants' names in position order.
+const ftsRows = `
+	SELECT id, name, (
+		SELECT group_concat(name, ', ') FROM (
+			SELECT name FROM event_participants WHERE event_participants.event_id = events.id ORDER BY position
+		)
+	)
+	FROM events`
+
+// searchSchema creates events_fts, an FTS5 index over event names and
+// participants, and the triggers keeping it in sync with events and
+// event_participants. An event's row is rebuilt whenever it or one of its
+// participants changes, as the participants are spread over several rows.
+var searchSchema = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS events_fts USING fts5(name, participants)`,
+	`CREATE TRIGGER IF NOT EXISTS events_fts_insert AFTER INSERT ON events BEGIN
+		INSERT INTO events_fts(rowid, name, participants) ` + ftsRows + ` WHERE id = new.id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS events_fts_delete AFTER DELETE ON events BEGIN
+		DELETE FROM events_fts WHERE rowid = old.id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS events_fts_update AFTER UPDATE OF name ON events BEGIN
+		DELETE FROM events_fts WHERE rowid = old.id;
+		INSERT INTO events_fts(rowid, name, participants) ` + ftsRows + ` WHERE id = new.id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS event_participants_fts_insert AFTER INSERT ON event_participants BEGIN
+		DELETE FROM events_fts WHERE rowid = new.event_id;
+		INSERT INTO events_fts(rowid, name, participants) ` + ftsRows + ` WHERE id = new.event_id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS event_participants_fts_delete AFTER DELETE ON event_participants BEGIN
+		DELETE FROM events_fts WHERE rowid = old.event_id;
+		INSERT INTO events_fts(rowid, name, participants) ` + ftsRows + ` WHERE id = old.event_id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS event_participants_fts_update AFTER UPDATE ON event_participants BEGIN
+		DELETE FROM events_fts WHERE rowid IN (old.event_id, new.event_id);
+		INSERT INTO events_fts(rowid, name, participants) ` + ftsRows + ` WHERE id IN (old.event_id, new.event_id);
+	END`,
+	// Catch up on changes made while the triggers didn't exist, e.g. by a
+	// build without FTS5.
+	`DELETE FROM events_fts`,
+	`INSERT INTO events_fts(rowid, name, participants) ` + ftsRows,
+}
+
+// searchTriggers are dropped when FTS5 isn't available, as they would fail
+// every write to events and event_participants.
+var searchTriggers = []string{
+	"events_fts_insert", "events_fts_delete", "events_fts_update",
+	"event_participants_fts_insert", "event_participants_fts_delete", "event_participants_fts_update",
+}
+
+// initSearch sets up the search index if the database is SQLite built with
+// FTS5, i.e. the service was built with -tags sqlite_fts5.
+func (r *eventsRepo) initSearch() error {
+	if r.dialect != dialect.SQLite {
+		return nil
+	}
+
+	searchable, err := fts.Init(r.db, searchSchema, searchTriggers)
+	if err != nil {
+		return err
+	}
+
+	r.searchable = searchable
+
+	return nil
+}
+
+// Search returns events whose name or participants contain every word of
+// query.
+func (r *eventsRepo) Search(ctx context.Context, query string, limit int32) ([]*EventSearchResult, error) {
+	if !r.searchable {
+		return nil, ErrSearchUnavailable
+	}
+
+	match := fts.Query(query)
+	if match == "" {
+		return nil, fmt.Errorf("%w: %q has no words to search for", ErrInvalidQuery, query)
+	}
+
+	rows, err := r.db.QueryContext(ctx, getEventQueries(r.dialect)[eventsSearch], r.now(), match, limit)
+	if err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+	defer rows.Close()
+
+	var (
+		results []*EventSearchResult
+		events  []*sports.Event
+	)
+	for rows.Next() {
+		var (
+			result = EventSearchResult{Event: &sports.Event{}}
+			rank   float64
+		)
+
+		if err := r.scanEvent(rows, result.Event, &result.Snippet, &rank); err != nil {
+			return nil, storage.Error(ctx, err)
+		}
+
+		// bm25 ranks better matches lower; scores are higher for them.
+		result.Score = -rank
+
+		results = append(results, &result)
+		events = append(events, result.Event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	if err := r.loadParticipants(ctx, events); err != nil {
+		return nil, storage.Error(ctx, err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEvent is an event to create for a search test.
+type testEvent struct {
+	name         string
+	participants []string
+}
+
+// replaceEvents replaces the events in repo's database with events, which
+// are given IDs from 1 in order.
+func replaceEvents(t *testing.T, repo *eventsRepo, events []testEvent) {
+	t.Helper()
+
+	_, err := repo.db.Exec(`DELETE FROM event_participants`)
+	require.NoError(t, err)
+	_, err = repo.db.Exec(`DELETE FROM events`)
+	require.NoError(t, err)
+
+	for i, event := range events {
+		_, err := repo.db.Exec(
+			repo.dialect.Rebind(`INSERT INTO events(id, name, advertised_start_time, sport, competition, venue) VALUES (?,?,?,?,?,?)`),
+			i+1, event.name, time.Now().Format(time.RFC3339), "AFL", "AFL Premiership", "MCG",
+		)
+		require.NoError(t, err)
+
+		for position, name := range event.participants {
+			_, err := repo.db.Exec(repo.dialect.Rebind(`INSERT INTO event_participants(event_id, position, name) VALUES (?,?,?)`), i+1, position, name)
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestEventsRepo_Search(t *testing.T) {
+	repo := newTestEventsRepo(t)
+	if !repo.searchable {
+		t.Skip("search needs -tags sqlite_fts5")
+	}
+
+	replaceEvents(t, repo, []testEvent{
+		{"Grand Final", []string{"Sydney Swans", "Melbourne Demons"}},
+		{"Round 1", []string{"Geelong Cats", "Sydney Swans"}},
+		{"Swans Charity Cup", []string{"Sydney Swans", "Melbourne Demons"}},
+		{"Round 2", []string{"Brisbane Lions", "Hawthorn"}},
+	})
+
+	// Every word must match, in any of the name and participants.
+	results, err := repo.Search(context.Background(), "swans mel", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// The event naming the query twice ranks first.
+	assert.Equal(t, int64(3), results[0].Event.Id)
+	assert.Equal(t, int64(1), results[1].Event.Id)
+	assert.Greater(t, results[0].Score, results[1].Score)
+	require.Len(t, results[1].Event.Participants, 2)
+	assert.Equal(t, "Sydney Swans", results[1].Event.Participants[0].Name)
+	assert.Equal(t, "MCG", results[1].Event.Venue)
+	assert.Contains(t, results[1].Snippet, "<mark>")
+
+	results, err = repo.Search(context.Background(), "haw", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Brisbane Lions, <mark>Hawthorn</mark>", results[0].Snippet)
+
+	results, err = repo.Search(context.Background(), "swans", 2)
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	// Renamed participants are reindexed.
+	_, err = repo.db.Exec(`UPDATE event_participants SET name = 'Hawks' WHERE event_id = 4 AND position = 1`)
+	require.NoError(t, err)
+	results, err = repo.Search(context.Background(), "hawthorn", 10)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// As are deleted events.
+	_, err = repo.db.Exec(`DELETE FROM event_participants WHERE event_id = 2`)
+	require.NoError(t, err)
+	_, err = repo.db.Exec(`DELETE FROM events WHERE id = 2`)
+	require.NoError(t, err)
+	results, err = repo.Search(context.Background(), "geelong", 10)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = repo.Search(context.Background(), "--", 10)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+// The seeded events are indexed.
+func TestEventsRepo_SearchSeeded(t *testing.T) {
+	repo := newTestEventsRepo(t)
+	if !repo.searchable {
+		t.Skip("search needs -tags sqlite_fts5")
+	}
+
+	event, err := repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	results, err := repo.Search(context.Background(), event.Participants[1].Name, 50)
+	require.NoError(t, err)
+
+	var ids []int64
+	for _, result := range results {
+		ids = append(ids, result.Event.Id)
+	}
+	assert.Contains(t, ids, event.Id)
+}
+
+func TestEventsRepo_SearchUnavailable(t *testing.T) {
+	repo := newTestEventsRepo(t)
+	if repo.searchable {
+		t.Skip("search is available")
+	}
+
+	_, err := repo.Search(context.Background(), "swans", 10)
+	assert.ErrorIs(t, err, ErrSearchUnavailable)
+
+	// Writes still work without the index.
+	replaceEvents(t, repo, []testEvent{{"Round 1", []string{"Geelong Cats", "Sydney Swans"}}})
+}
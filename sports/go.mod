@@ -0,0 +1,21 @@
+module github.com/Kim-Hardie/entain-master/sports
+
+go 1.16
+
+require (
+	github.com/Kim-Hardie/entain-master/proto v0.0.0-00010101000000-000000000000
+	github.com/Kim-Hardie/entain-master/storage v0.0.0-00010101000000-000000000000
+	github.com/golang/protobuf v1.4.3
+	github.com/lib/pq v1.10.9
+	github.com/mattn/go-sqlite3 v1.14.6
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/net v0.0.0-20210226172049-e18ecbb05110 // indirect
+	google.golang.org/genproto v0.0.0-20210226172003-ab064af71705
+	google.golang.org/grpc v1.36.0
+	google.golang.org/protobuf v1.25.1-0.20201208041424-160c7477e0e8
+	syreclabs.com/go/faker v1.2.3
+)
+
+replace github.com/Kim-Hardie/entain-master/proto => ../proto
+
+replace github.com/Kim-Hardie/entain-master/storage => ../storage
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"log"
+	"net"
+
+	"github.com/Kim-Hardie/entain-master/proto/sports"
+	"github.com/Kim-Hardie/entain-master/sports/db"
+	"github.com/Kim-Hardie/entain-master/sports/service"
+	"github.com/Kim-Hardie/entain-master/storage/dialect"
+	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
+)
+
+var (
+	grpcEndpoint = flag.String("grpc-endpoint", "localhost:9001", "gRPC server endpoint")
+	dbDriver     = flag.String("db-driver", dialect.SQLiteDriver, "database driver, sqlite3 or postgres")
+	dbDSN        = flag.String("db-dsn", "./db/sports.db", "database data source name, a file path for sqlite3 or a connection string for postgres")
+)
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		log.Fatalf("failed running grpc server: %s\n", err)
+	}
+}
+
+func run() error {
+	conn, err := net.Listen("tcp", *grpcEndpoint)
+	if err != nil {
+		return err
+	}
+
+	if _, err := dialect.ForDriver(*dbDriver); err != nil {
+		return err
+	}
+
+	sportsDB, err := sql.Open(*dbDriver, *dbDSN)
+	if err != nil {
+		return err
+	}
+
+	eventsRepo := db.NewEventsRepo(sportsDB)
+	if err := eventsRepo.Init(); err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer()
+
+	sports.RegisterSportsServer(
+		grpcServer,
+		service.NewSportsService(eventsRepo),
+	)
+
+	log.Printf("gRPC server listening on: %s\n", *grpcEndpoint)
+
+	if err := grpcServer.Serve(conn); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/Kim-Hardie/entain-master/proto/sports"
+	"github.com/Kim-Hardie/entain-master/sports/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type MockEventsRepo struct {
+	Events []*pb.Event
+	// SearchErr, if set, is returned by Search.
+	SearchErr error
+}
+
+func (m *MockEventsRepo) Init() error {
+	return nil
+}
+
+func (m *MockEventsRepo) List(ctx context.Context, filter *pb.ListEventsRequestFilter) ([]*pb.Event, error) {
+	var events []*pb.Event
+	for _, event := range m.Events {
+		if (filter.GetSport() == "" || event.Sport == filter.GetSport()) &&
+			(filter.GetCompetition() == "" || event.Competition == filter.GetCompetition()) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (m *MockEventsRepo) GetByID(ctx context.Context, id int64) (*pb.Event, error) {
+	for _, event := range m.Events {
+		if event.Id == id {
+			return event, nil
+		}
+	}
+	return nil, fmt.Errorf("event %d: %w", id, db.ErrNotFound)
+}
+
+// Search finds events whose name contains query.
+func (m *MockEventsRepo) Search(ctx context.Context, query string, limit int32) ([]*db.EventSearchResult, error) {
+	if m.SearchErr != nil {
+		return nil, m.SearchErr
+	}
+
+	var results []*db.EventSearchResult
+	for _, event := range m.Events {
+		if len(results) < int(limit) && strings.Contains(strings.ToLower(event.Name), strings.ToLower(query)) {
+			results = append(results, &db.EventSearchResult{Event: event, Snippet: event.Name})
+		}
+	}
+	return results, nil
+}
+
+// matchEvents are events as the deprecated match RPCs see them.
+var matchEvents = []*pb.Event{
+	{
+		Id:           1,
+		Name:         "Match 1",
+		Venue:        "Stadium A",
+		Sport:        "Sport A",
+		Participants: []*pb.Participant{{Id: 1, Name: "Team A"}, {Id: 2, Name: "Team B"}},
+	},
+	{
+		Id:           2,
+		Name:         "Match 2",
+		Venue:        "Stadium B",
+		Sport:        "Sport A",
+		Participants: []*pb.Participant{{Id: 3, Name: "Team C"}, {Id: 4, Name: "Team D"}},
+	},
+	{
+		Id:    3,
+		Name:  "Match 3",
+		Venue: "Stadium C",
+		Sport: "Sport B",
+	},
+}
+
+func TestSportsService_ListMatches(t *testing.T) {
+	// Create a new SportsService instance with the mock repository
+	service := NewSportsService(&MockEventsRepo{Events: matchEvents})
+
+	// Create a ListMatchesRequest with the filter by sport
+	sportFilter := &pb.MatchFilter{
+		Sport: "Sport A",
+	}
+	request := &pb.ListMatchesRequest{
+		Filter: sportFilter,
+	}
+
+	// Call the ListMatches method
+	response, err := service.ListMatches(context.Background(), request)
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Len(t, response.Matches, 2)
+
+	// Create a ListMatchesRequest with the filter by stadium, which is the
+	// event's venue
+	stadiumFilter := &pb.MatchFilter{
+		Stadium: "Stadium B",
+	}
+	request = &pb.ListMatchesRequest{
+		Filter: stadiumFilter,
+	}
+
+	// Call the ListMatches method
+	response, err = service.ListMatches(context.Background(), request)
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	require.Len(t, response.Matches, 1)
+	assert.Equal(t, int64(2), response.Matches[0].Id)
+	assert.Equal(t, "Stadium B", response.Matches[0].Stadium)
+	assert.Equal(t, "Team C", response.Matches[0].Team1)
+	assert.Equal(t, "Team D", response.Matches[0].Team2)
+
+	// Events without participants have no teams.
+	response, err = service.ListMatches(context.Background(), &pb.ListMatchesRequest{Filter: &pb.MatchFilter{Sport: "Sport B"}})
+	assert.NoError(t, err)
+	require.Len(t, response.Matches, 1)
+	assert.Empty(t, response.Matches[0].Team1)
+	assert.Empty(t, response.Matches[0].Team2)
+}
+
+func TestSportsService_ListMatchesInvalidStartTimeWindow(t *testing.T) {
+	service := NewSportsService(&MockEventsRepo{})
+
+	now := time.Now()
+	for _, filter := range []*pb.MatchFilter{
+		{StartTimeFrom: timestamppb.New(now), StartTimeTo: timestamppb.New(now)},
+		{StartTimeFrom: timestamppb.New(now), StartTimeTo: timestamppb.New(now.Add(-time.Hour))},
+		{StartTimeFrom: &timestamppb.Timestamp{Nanos: -1}},
+	} {
+		_, err := service.ListMatches(context.Background(), &pb.ListMatchesRequest{Filter: filter})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestSportsService_GetMatchByID(t *testing.T) {
+	// Create a new SportsService instance with the mock repository
+	service := NewSportsService(&MockEventsRepo{Events: matchEvents})
+
+	// Create a GetMatchByIDRequest for an existing match
+	request := &pb.GetMatchByIDRequest{
+		MatchId: 2,
+	}
+
+	// Call the GetMatchByID method
+	response, err := service.GetMatchByID(context.Background(), request)
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, int64(2), response.Match.Id)
+	assert.Equal(t, "Match 2", response.Match.Name)
+	assert.Equal(t, "Stadium B", response.Match.Stadium)
+	assert.Equal(t, "Team C", response.Match.Team1)
+	assert.Equal(t, "Team D", response.Match.Team2)
+
+	// A missing match is NotFound.
+	_, err = service.GetMatchByID(context.Background(), &pb.GetMatchByIDRequest{MatchId: 4})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSportsService_SearchMatches(t *testing.T) {
+	service := NewSportsService(&MockEventsRepo{
+		Events: []*pb.Event{
+			{Id: 1, Name: "Swans v Demons", Participants: []*pb.Participant{{Id: 1, Name: "Swans"}, {Id: 2, Name: "Demons"}}},
+			{Id: 2, Name: "Cats v Swans"},
+			{Id: 3, Name: "Lions v Hawks"},
+		},
+	})
+
+	response, err := service.SearchMatches(context.Background(), &pb.SearchMatchesRequest{Query: "swans"})
+	require.NoError(t, err)
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, int64(1), response.Results[0].Match.Id)
+	assert.Equal(t, "Demons", response.Results[0].Match.Team2)
+	assert.Equal(t, int64(2), response.Results[1].Match.Id)
+
+	response, err = service.SearchMatches(context.Background(), &pb.SearchMatchesRequest{Query: "swans", PageSize: 1})
+	require.NoError(t, err)
+	assert.Len(t, response.Results, 1)
+
+	for _, request := range []*pb.SearchMatchesRequest{{Query: " "}, {Query: "swans", PageSize: -1}} {
+		_, err = service.SearchMatches(context.Background(), request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+// Without FTS5 search is Unimplemented, as retrying won't help.
+func TestSportsService_SearchMatchesUnavailable(t *testing.T) {
+	service := NewSportsService(&MockEventsRepo{SearchErr: db.ErrSearchUnavailable})
+
+	_, err := service.SearchMatches(context.Background(), &pb.SearchMatchesRequest{Query: "swans"})
+	st := status.Convert(err)
+	assert.Equal(t, codes.Unimplemented, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, reasonSearchUnavailable, info.Reason)
+}
+
+func TestSportsService_ListEvents(t *testing.T) {
+	mockEvents := &MockEventsRepo{
+		Events: []*pb.Event{
+			{Id: 1, Name: "Event 1", Sport: "AFL", Competition: "AFL Premiership"},
+			{Id: 2, Name: "Event 2", Sport: "Cricket", Competition: "Big Bash League"},
+			{Id: 3, Name: "Event 3", Sport: "Cricket", Competition: "Sheffield Shield"},
+		},
+	}
+	service := NewSportsService(mockEvents)
+
+	response, err := service.ListEvents(context.Background(), &pb.ListEventsRequest{Filter: &pb.ListEventsRequestFilter{Sport: "Cricket"}})
+	assert.NoError(t, err)
+	assert.Len(t, response.Events, 2)
+
+	response, err = service.ListEvents(context.Background(), &pb.ListEventsRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, response.Events, 3)
+
+	now := time.Now()
+	_, err = service.ListEvents(context.Background(), &pb.ListEventsRequest{Filter: &pb.ListEventsRequestFilter{
+		StartTimeFrom: timestamppb.New(now),
+		StartTimeTo:   timestamppb.New(now),
+	}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSportsService_GetEvent(t *testing.T) {
+	mockEvents := &MockEventsRepo{
+		Events: []*pb.Event{
+			{Id: 1, Name: "Event 1", Participants: []*pb.Participant{{Id: 1, Name: "Team A"}, {Id: 2, Name: "Team B"}}},
+		},
+	}
+	service := NewSportsService(mockEvents)
+
+	event, err := service.GetEvent(context.Background(), &pb.GetEventRequest{EventId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "Event 1", event.Name)
+	assert.Len(t, event.Participants, 2)
+
+	// A missing event is NotFound rather than an empty response, with an
+	// ErrorInfo detail saying which event.
+	_, err = service.GetEvent(context.Background(), &pb.GetEventRequest{EventId: 2})
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, reasonEventNotFound, info.Reason)
+	assert.Equal(t, "2", info.Metadata["event_id"])
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Kim-Hardie/entain-master/sports/db"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain identifies the sports service in ErrorInfo details.
+const errorDomain = "sports.Sports"
+
+// Reasons set on ErrorInfo details, so clients can tell errors sharing a code
+// apart without parsing messages.
+const (
+	reasonEventNotFound          = "EVENT_NOT_FOUND"
+	reasonMatchNotFound          = "MATCH_NOT_FOUND"
+	reasonInvalidStartTimeWindow = "INVALID_START_TIME_WINDOW"
+	reasonInvalidPageSize        = "INVALID_PAGE_SIZE"
+	reasonInvalidQuery           = "INVALID_QUERY"
+	reasonSearchUnavailable      = "SEARCH_UNAVAILABLE"
+	reasonStorageUnavailable     = "STORAGE_UNAVAILABLE"
+)
+
+// statusError returns a gRPC error with the given code and message, carrying
+// an ErrorInfo detail with reason and metadata.
+func statusError(code codes.Code, reason string, metadata map[string]string, msg string) error {
+	st := status.New(code, msg)
+
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// repoError maps an error from the events or matches repository onto a gRPC
+// status. notFoundReason is the reason used should the record be missing, and
+// metadata describes the request, e.g. the event asked for.
+func repoError(err error, notFoundReason string, metadata map[string]string) error {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return statusError(codes.NotFound, notFoundReason, metadata, err.Error())
+	case errors.Is(err, db.ErrInvalidQuery):
+		return statusError(codes.InvalidArgument, reasonInvalidQuery, metadata, err.Error())
+	case errors.Is(err, db.ErrSearchUnavailable):
+		return statusError(codes.Unimplemented, reasonSearchUnavailable, metadata, err.Error())
+	case errors.Is(err, db.ErrUnavailable):
+		return statusError(codes.Unavailable, reasonStorageUnavailable, metadata, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
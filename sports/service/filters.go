@@ -0,0 +1,30 @@
+package service
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// validateStartTimeWindow checks the start_time_from/start_time_to filters
+// are valid timestamps describing a non-empty window.
+func validateStartTimeWindow(from, to *timestamppb.Timestamp) error {
+	if from != nil {
+		if err := from.CheckValid(); err != nil {
+			return statusError(codes.InvalidArgument, reasonInvalidStartTimeWindow, nil, fmt.Sprintf("invalid start_time_from: %s", err))
+		}
+	}
+
+	if to != nil {
+		if err := to.CheckValid(); err != nil {
+			return statusError(codes.InvalidArgument, reasonInvalidStartTimeWindow, nil, fmt.Sprintf("invalid start_time_to: %s", err))
+		}
+	}
+
+	if from != nil && to != nil && !from.AsTime().Before(to.AsTime()) {
+		return statusError(codes.InvalidArgument, reasonInvalidStartTimeWindow, nil, "start_time_from must be before start_time_to")
+	}
+
+	return nil
+}
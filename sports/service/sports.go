@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	pb "github.com/Kim-Hardie/entain-master/proto/sports"
+	"github.com/Kim-Hardie/entain-master/sports/db"
+	"github.com/Kim-Hardie/entain-master/storage"
+	"google.golang.org/grpc/codes"
+)
+
+// SportsService implements the SportsServer interface.
+type SportsService struct {
+	pb.UnimplementedSportsServer
+	eventsRepo db.EventsRepo
+}
+
+// NewSportsService instantiates and returns a new SportsService.
+func NewSportsService(eventsRepo db.EventsRepo) *SportsService {
+	return &SportsService{eventsRepo: eventsRepo}
+}
+
+func (s *SportsService) ListEvents(ctx context.Context, in *pb.ListEventsRequest) (*pb.ListEventsResponse, error) {
+	if err := validateStartTimeWindow(in.GetFilter().GetStartTimeFrom(), in.GetFilter().GetStartTimeTo()); err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventsRepo.List(ctx, in.Filter)
+	if err != nil {
+		return nil, repoError(err, reasonEventNotFound, nil)
+	}
+
+	return &pb.ListEventsResponse{Events: events}, nil
+}
+
+func (s *SportsService) GetEvent(ctx context.Context, req *pb.GetEventRequest) (*pb.Event, error) {
+	event, err := s.eventsRepo.GetByID(ctx, req.EventId)
+	if err != nil {
+		return nil, repoError(err, reasonEventNotFound, map[string]string{"event_id": strconv.FormatInt(req.EventId, 10)})
+	}
+
+	return event, nil
+}
+
+// ListMatches is deprecated in favour of ListEvents and kept while clients
+// migrate. Matches are events, filtered by stadium on their venue.
+func (s *SportsService) ListMatches(ctx context.Context, in *pb.ListMatchesRequest) (*pb.ListMatchesResponse, error) {
+	if err := validateStartTimeWindow(in.GetFilter().GetStartTimeFrom(), in.GetFilter().GetStartTimeTo()); err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventsRepo.List(ctx, &pb.ListEventsRequestFilter{
+		Sport:         in.GetFilter().GetSport(),
+		StartTimeFrom: in.GetFilter().GetStartTimeFrom(),
+		StartTimeTo:   in.GetFilter().GetStartTimeTo(),
+	})
+	if err != nil {
+		return nil, repoError(err, reasonMatchNotFound, nil)
+	}
+
+	var matches []*pb.Match
+	for _, event := range events {
+		if stadium := in.GetFilter().GetStadium(); stadium != "" && event.Venue != stadium {
+			continue
+		}
+		matches = append(matches, matchFromEvent(event))
+	}
+
+	return &pb.ListMatchesResponse{Matches: matches}, nil
+}
+
+// GetMatchByID is deprecated in favour of GetEvent and kept while clients migrate.
+func (s *SportsService) GetMatchByID(ctx context.Context, req *pb.GetMatchByIDRequest) (*pb.GetMatchByIDResponse, error) {
+	event, err := s.eventsRepo.GetByID(ctx, req.MatchId)
+	if err != nil {
+		return nil, repoError(err, reasonMatchNotFound, map[string]string{"match_id": strconv.FormatInt(req.MatchId, 10)})
+	}
+
+	return &pb.GetMatchByIDResponse{
+		Match: matchFromEvent(event),
+	}, nil
+}
+
+func (s *SportsService) SearchMatches(ctx context.Context, req *pb.SearchMatchesRequest) (*pb.SearchMatchesResponse, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, statusError(codes.InvalidArgument, reasonInvalidQuery, nil, "query is required")
+	}
+
+	pageSize, err := storage.PageSize(req.PageSize)
+	if err != nil {
+		return nil, statusError(codes.InvalidArgument, reasonInvalidPageSize, nil, err.Error())
+	}
+
+	found, err := s.eventsRepo.Search(ctx, req.Query, pageSize)
+	if err != nil {
+		return nil, repoError(err, reasonMatchNotFound, nil)
+	}
+
+	results := make([]*pb.MatchSearchResult, 0, len(found))
+	for _, result := range found {
+		results = append(results, &pb.MatchSearchResult{
+			Match:   matchFromEvent(result.Event),
+			Snippet: result.Snippet,
+			Score:   result.Score,
+		})
+	}
+
+	return &pb.SearchMatchesResponse{Results: results}, nil
+}
+
+// matchFromEvent returns event as the deprecated Match: its venue is the
+// stadium and its first two participants are the teams.
+func matchFromEvent(event *pb.Event) *pb.Match {
+	match := &pb.Match{
+		Id:      event.Id,
+		Name:    event.Name,
+		Stadium: event.Venue,
+		Sport:   event.Sport,
+		Time:    event.AdvertisedStartTime,
+	}
+
+	if len(event.Participants) > 0 {
+		match.Team1 = event.Participants[0].Name
+	}
+	if len(event.Participants) > 1 {
+		match.Team2 = event.Participants[1].Name
+	}
+
+	return match
+}
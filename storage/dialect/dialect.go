@@ -0,0 +1,130 @@
+// Package dialect describes how SQL differs between the databases the racing
+// and sports services can store their data in, SQLite and PostgreSQL.
+//
+// Queries are written once with ? placeholders and SQLite-compatible SQL, then
+// adapted with Rebind and Timestamp.
+package dialect
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Driver names accepted by ForDriver, matching their database/sql drivers.
+const (
+	SQLiteDriver   = "sqlite3"
+	PostgresDriver = "postgres"
+)
+
+// Dialect adapts queries to a particular database.
+type Dialect interface {
+	// Driver returns the database/sql driver name.
+	Driver() string
+
+	// Rebind rewrites a query written with ? placeholders into the
+	// dialect's placeholder style.
+	Rebind(query string) string
+
+	// Timestamp wraps a timestamp column or placeholder so that it compares
+	// and sorts chronologically.
+	Timestamp(expr string) string
+
+	// TimestampType is the column type used to store timestamps.
+	TimestampType() string
+
+	// SerialPrimaryKey is the column definition of an auto-incrementing
+	// primary key.
+	SerialPrimaryKey() string
+
+	// TableExistsQuery returns a query counting the tables named by its one
+	// placeholder.
+	TableExistsQuery() string
+}
+
+var (
+	// SQLite stores timestamps as text, possibly with any UTC offset, so
+	// they're compared through datetime().
+	SQLite Dialect = sqlite{}
+
+	// Postgres stores timestamps as TIMESTAMPTZ and numbers placeholders.
+	Postgres Dialect = postgres{}
+)
+
+// ForDriver returns the dialect for a database/sql driver name.
+func ForDriver(driver string) (Dialect, error) {
+	switch driver {
+	case SQLiteDriver:
+		return SQLite, nil
+	case PostgresDriver:
+		return Postgres, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q, want %q or %q", driver, SQLiteDriver, PostgresDriver)
+	}
+}
+
+// Of returns the dialect of an open database, defaulting to SQLite.
+func Of(db *sql.DB) Dialect {
+	if _, ok := db.Driver().(*pq.Driver); ok {
+		return Postgres
+	}
+
+	return SQLite
+}
+
+type sqlite struct{}
+
+func (sqlite) Driver() string { return SQLiteDriver }
+
+func (sqlite) Rebind(query string) string { return query }
+
+func (sqlite) Timestamp(expr string) string { return "datetime(" + expr + ")" }
+
+func (sqlite) TimestampType() string { return "DATETIME" }
+
+func (sqlite) SerialPrimaryKey() string { return "INTEGER PRIMARY KEY AUTOINCREMENT" }
+
+func (sqlite) TableExistsQuery() string {
+	return `SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?`
+}
+
+type postgres struct{}
+
+func (postgres) Driver() string { return PostgresDriver }
+
+// Rebind numbers placeholders $1, $2, ..., leaving question marks inside
+// quoted strings alone.
+func (postgres) Rebind(query string) string {
+	var (
+		b        strings.Builder
+		n        int
+		inString bool
+	)
+
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			inString = !inString
+		case r == '?' && !inString:
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func (postgres) Timestamp(expr string) string { return expr }
+
+func (postgres) TimestampType() string { return "TIMESTAMPTZ" }
+
+func (postgres) SerialPrimaryKey() string { return "BIGSERIAL PRIMARY KEY" }
+
+func (postgres) TableExistsQuery() string {
+	return `SELECT count(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?`
+}
@@ -0,0 +1,27 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebind(t *testing.T) {
+	query := `SELECT * FROM races WHERE status = 'OPEN?' AND id IN (?, ?) LIMIT ?`
+
+	assert.Equal(t, query, SQLite.Rebind(query))
+	assert.Equal(t, `SELECT * FROM races WHERE status = 'OPEN?' AND id IN ($1, $2) LIMIT $3`, Postgres.Rebind(query))
+}
+
+func TestForDriver(t *testing.T) {
+	d, err := ForDriver("sqlite3")
+	assert.NoError(t, err)
+	assert.Equal(t, SQLite, d)
+
+	d, err = ForDriver("postgres")
+	assert.NoError(t, err)
+	assert.Equal(t, Postgres, d)
+
+	_, err = ForDriver("mysql")
+	assert.Error(t, err)
+}
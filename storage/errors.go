@@ -0,0 +1,73 @@
+// Package storage holds what the racing and sports repositories share about
+// their databases: the SQL dialects in the dialect package, FTS5 search in the
+// fts package, how driver errors are classified and how large a page of
+// results may be.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// ErrUnavailable is returned when the database can't currently serve the
+// request, e.g. it is locked, can't be opened or can't be reached. Retrying
+// may succeed.
+var ErrUnavailable = errors.New("storage unavailable")
+
+// Error wraps err in ErrUnavailable when it signals a transient problem
+// with the database rather than with the query, and returns it unchanged
+// otherwise. If ctx has ended the error is reported as the context's, as
+// PostgreSQL fails a cancelled query with an error of its own.
+func Error(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if errors.Is(err, ctxErr) {
+			return err
+		}
+		return fmt.Errorf("%w: %s", ctxErr, err)
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) {
+		return fmt.Errorf("%w: %s", ErrUnavailable, err)
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code {
+		case sqlite3.ErrBusy, sqlite3.ErrLocked, sqlite3.ErrCantOpen, sqlite3.ErrIoErr, sqlite3.ErrFull:
+			return fmt.Errorf("%w: %s", ErrUnavailable, err)
+		}
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Class() {
+		// connection_exception, insufficient_resources and
+		// operator_intervention, e.g. the server is shutting down.
+		case "08", "53", "57":
+			return fmt.Errorf("%w: %s", ErrUnavailable, err)
+		}
+		switch pqErr.Code.Name() {
+		case "serialization_failure", "deadlock_detected", "lock_not_available":
+			return fmt.Errorf("%w: %s", ErrUnavailable, err)
+		}
+	}
+
+	// The PostgreSQL server can't be reached.
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return fmt.Errorf("%w: %s", ErrUnavailable, err)
+	}
+
+	return err
+}
@@ -0,0 +1,78 @@
+// Package fts holds what the racing and sports repositories share about
+// searching with SQLite's FTS5 full-text index, which is only compiled in by
+// building with -tags sqlite_fts5.
+package fts
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// SnippetStart and SnippetEnd mark the words a search matched in its
+	// snippets.
+	SnippetStart = "<mark>"
+	SnippetEnd   = "</mark>"
+	// Ellipsis marks where a snippet was cut from longer text.
+	Ellipsis = "…"
+	// SnippetTokens is roughly how many words a snippet holds.
+	SnippetTokens = 10
+)
+
+// Init creates an index with schema if the SQLite database was built with
+// FTS5, and reports whether it did. Otherwise it drops triggers, the
+// triggers schema keeps the index in sync with, as they would fail every
+// write to the indexed table.
+func Init(db *sql.DB, schema, triggers []string) (bool, error) {
+	var enabled bool
+	if err := db.QueryRow(`SELECT sqlite_compileoption_used('ENABLE_FTS5')`).Scan(&enabled); err != nil {
+		return false, err
+	}
+
+	if !enabled {
+		// A build with FTS5 may have used this database before.
+		for _, trigger := range triggers {
+			if _, err := db.Exec(`DROP TRIGGER IF EXISTS ` + trigger); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	for _, statement := range schema {
+		if _, err := db.Exec(statement); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Snippet returns the SQL for a snippet of column of table around the words
+// a search matched, marked with SnippetStart and SnippetEnd. A column of -1
+// takes it from whichever column matched best.
+func Snippet(table string, column int) string {
+	return fmt.Sprintf("snippet(%s, %d, '%s', '%s', '%s', %d)", table, column, SnippetStart, SnippetEnd, Ellipsis, SnippetTokens)
+}
+
+// Query turns text typed into a search box into an FTS5 query matching every
+// word in it, the last as a prefix so results appear as the user types.
+// Punctuation is dropped rather than read as query syntax. It returns "" if
+// text has no words.
+func Query(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"`
+	}
+	if len(terms) > 0 {
+		terms[len(terms)-1] += "*"
+	}
+
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,69 @@
+package fts
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	tests := map[string]string{
+		"cox":               `"cox"*`,
+		"  Cox   pl ":       `"Cox" "pl"*`,
+		`cox" OR name:*`:    `"cox" "OR" "name"*`,
+		`swans" OR team1:*`: `"swans" "OR" "team1"*`,
+		"Mooney Valley-":    `"Mooney" "Valley"*`,
+		"--":                "",
+		"":                  "",
+	}
+
+	for text, want := range tests {
+		assert.Equal(t, want, Query(text), text)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	assert.Equal(t, `snippet(races_fts, 0, '<mark>', '</mark>', '…', 10)`, Snippet("races_fts", 0))
+}
+
+func TestInit(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE names (id INTEGER PRIMARY KEY, name TEXT)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TRIGGER names_stale AFTER INSERT ON names BEGIN SELECT 1; END`)
+	require.NoError(t, err)
+
+	schema := []string{
+		`CREATE VIRTUAL TABLE IF NOT EXISTS names_fts USING fts5(name, content='names', content_rowid='id')`,
+		`INSERT INTO names_fts(names_fts) VALUES ('rebuild')`,
+	}
+	enabled, err := Init(db, schema, []string{"names_stale"})
+	require.NoError(t, err)
+
+	var triggers int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM sqlite_master WHERE type = 'trigger'`).Scan(&triggers))
+
+	if !enabled {
+		// Without FTS5 the triggers are dropped and no index is made.
+		assert.Zero(t, triggers)
+		return
+	}
+
+	assert.Equal(t, 1, triggers)
+
+	_, err = db.Exec(`INSERT INTO names(name) VALUES ('Cox Plate')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO names_fts(names_fts) VALUES ('rebuild')`)
+	require.NoError(t, err)
+
+	var snippet string
+	require.NoError(t, db.QueryRow(`SELECT `+Snippet("names_fts", 0)+` FROM names_fts WHERE names_fts MATCH ?`, Query("cox")).Scan(&snippet))
+	assert.Equal(t, "<mark>Cox</mark> Plate", snippet)
+}
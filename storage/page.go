@@ -0,0 +1,29 @@
+package storage
+
+import "errors"
+
+const (
+	// DefaultPageSize is used when a list or search request doesn't set
+	// page_size.
+	DefaultPageSize = 100
+	// MaxPageSize caps page_size so a single response stays reasonably small.
+	MaxPageSize = 1000
+)
+
+// ErrInvalidPageSize is returned by PageSize for a negative page size.
+var ErrInvalidPageSize = errors.New("page_size must not be negative")
+
+// PageSize defaults an unset page size to DefaultPageSize and caps a large one
+// at MaxPageSize. It returns ErrInvalidPageSize if pageSize is negative.
+func PageSize(pageSize int32) (int32, error) {
+	switch {
+	case pageSize < 0:
+		return 0, ErrInvalidPageSize
+	case pageSize == 0:
+		return DefaultPageSize, nil
+	case pageSize > MaxPageSize:
+		return MaxPageSize, nil
+	}
+
+	return pageSize, nil
+}
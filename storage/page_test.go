@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageSize(t *testing.T) {
+	tests := []struct {
+		pageSize int32
+		want     int32
+		err      error
+	}{
+		{0, DefaultPageSize, nil},
+		{1, 1, nil},
+		{MaxPageSize, MaxPageSize, nil},
+		{MaxPageSize + 1, MaxPageSize, nil},
+		{-1, 0, ErrInvalidPageSize},
+	}
+
+	for _, tt := range tests {
+		got, err := PageSize(tt.pageSize)
+		assert.Equal(t, tt.want, got, "page size %d", tt.pageSize)
+		assert.Equal(t, tt.err, err, "page size %d", tt.pageSize)
+	}
+}